@@ -2,6 +2,7 @@ package args
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -9,14 +10,44 @@ import (
 	teetypes "github.com/masa-finance/tee-types/types"
 )
 
+var (
+	ErrLinkedInMaxResultsNegative  = errors.New("must be non-negative")
+	ErrLinkedInStartNegative       = errors.New("must be non-negative")
+	ErrLinkedInProfileRequired     = errors.New("getprofile requires a public_identifier or a profile_url")
+	ErrLinkedInProfileURLInvalid   = errors.New("invalid LinkedIn profile URL")
+	ErrLinkedInNotProfileURL       = errors.New("URL is not a LinkedIn profile URL")
+	ErrLinkedInIdentifierInvalid   = errors.New("invalid identifier, expected 3-100 letters, numbers or hyphens")
+	ErrLinkedInProfileMismatch     = errors.New("public_identifier and profile_url refer to different profiles")
+	ErrLinkedInCompanyRequired     = errors.New("getcompany requires a company_slug or a company_url")
+	ErrLinkedInCompanyURLInvalid   = errors.New("invalid LinkedIn company URL")
+	ErrLinkedInNotCompanyURL       = errors.New("URL is not a LinkedIn company URL")
+	ErrLinkedInCompanySlugInvalid  = errors.New("invalid slug, expected letters, numbers or hyphens")
+	ErrLinkedInCompanyMismatch     = errors.New("company_slug and company_url refer to different companies")
+	ErrLinkedInJobSearchRequired   = errors.New("searchjobs requires a query, a location or a company filter")
+	ErrLinkedInInvalidWorkplace    = errors.New("invalid workplace type")
+	ErrLinkedInInvalidExperience   = errors.New("invalid experience level")
+	ErrLinkedInInvalidDatePosted   = errors.New("unknown date range")
+	ErrLinkedInJobFilterNotAllowed = errors.New("only supported by searchjobs")
+)
+
 // LinkedInArguments defines args for LinkedIn operations
 type LinkedInArguments struct {
-	QueryType        string   `json:"type"`  // "searchbyquery", "getprofile"
-	Query            string   `json:"query"` // Keywords for search or username for profile
+	QueryType        string   `json:"type"`  // "searchbyquery", "getprofile", "getcompany", "searchjobs"
+	Query            string   `json:"query"` // Keywords for search, username for profile or slug for company
 	PublicIdentifier string   `json:"public_identifier,omitempty"`
+	ProfileURL       string   `json:"profile_url,omitempty"`     // Alternative to PublicIdentifier, e.g. https://www.linkedin.com/in/john-doe/
+	CompanySlug      string   `json:"company_slug,omitempty"`    // e.g. "microsoft"
+	CompanyURL       string   `json:"company_url,omitempty"`     // Alternative to CompanySlug, e.g. https://www.linkedin.com/company/microsoft/
 	NetworkFilters   []string `json:"network_filters,omitempty"` // ["F", "S", "O"] - First, Second, Other (default: all)
 	MaxResults       int      `json:"max_results"`               // Maximum number of results to return
 	Start            int      `json:"start"`                     // Pagination start offset
+
+	// Job search filters, only for searchjobs
+	Location         string                             `json:"location,omitempty"` // e.g. "London, United Kingdom"
+	WorkplaceTypes   []teetypes.LinkedInWorkplaceType   `json:"workplace_types,omitempty"`
+	ExperienceLevels []teetypes.LinkedInExperienceLevel `json:"experience_levels,omitempty"`
+	DatePosted       teetypes.LinkedInDatePosted        `json:"date_posted,omitempty"`
+	Companies        []string                           `json:"companies,omitempty"` // Company slugs or URLs
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling with validation
@@ -36,6 +67,14 @@ func (l *LinkedInArguments) UnmarshalJSON(data []byte) error {
 	// Normalize QueryType to lowercase
 	l.QueryType = strings.ToLower(l.QueryType)
 
+	for i, w := range l.WorkplaceTypes {
+		l.WorkplaceTypes[i] = teetypes.LinkedInWorkplaceType(strings.ToLower(string(w)))
+	}
+	for i, e := range l.ExperienceLevels {
+		l.ExperienceLevels[i] = teetypes.LinkedInExperienceLevel(strings.ToLower(string(e)))
+	}
+	l.DatePosted = teetypes.LinkedInDatePosted(strings.ToLower(string(l.DatePosted)))
+
 	return l.Validate()
 }
 
@@ -43,26 +82,196 @@ func (l *LinkedInArguments) UnmarshalJSON(data []byte) error {
 func (l *LinkedInArguments) Validate() error {
 	// Note: QueryType is not required for all capabilities, similar to Twitter pattern
 	// Query is also not required for all capabilities
+	var errs []error
 
 	if l.MaxResults < 0 {
-		return fmt.Errorf("max_results must be non-negative, got: %d", l.MaxResults)
+		errs = append(errs, NewValidationError("max_results", CodeOutOfRange, l.MaxResults, fmt.Errorf("%w, got: %d", ErrLinkedInMaxResultsNegative, l.MaxResults)))
 	}
 
 	if l.Start < 0 {
-		return fmt.Errorf("start must be non-negative, got: %d", l.Start)
+		errs = append(errs, NewValidationError("start", CodeOutOfRange, l.Start, fmt.Errorf("%w, got: %d", ErrLinkedInStartNegative, l.Start)))
+	}
+
+	if l.IsProfileOperation() {
+		if _, err := l.resolvePublicIdentifier(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if l.IsCompanyOperation() {
+		if _, err := l.resolveCompanySlug(); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
-	return nil
+	errs = append(errs, l.validateJobFilters()...)
+
+	return errors.Join(errs...)
 }
 
-// ValidateForJobType validates LinkedIn arguments for a specific job type
-func (l *LinkedInArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := l.Validate(); err != nil {
-		return err
+// linkedInTarget describes an entity that LinkedIn arguments can identify either by a bare identifier or by a URL
+type linkedInTarget struct {
+	kind       teetypes.LinkedInURLKind
+	normalize  func(string) (string, bool)
+	required   error
+	urlInvalid error
+	wrongKind  error
+	idInvalid  error
+	mismatch   error
+}
+
+var (
+	linkedInProfileTarget = linkedInTarget{
+		kind:       teetypes.LinkedInProfileURL,
+		normalize:  teetypes.NormalizeLinkedInIdentifier,
+		required:   ErrLinkedInProfileRequired,
+		urlInvalid: ErrLinkedInProfileURLInvalid,
+		wrongKind:  ErrLinkedInNotProfileURL,
+		idInvalid:  ErrLinkedInIdentifierInvalid,
+		mismatch:   ErrLinkedInProfileMismatch,
+	}
+	linkedInCompanyTarget = linkedInTarget{
+		kind:       teetypes.LinkedInCompanyURL,
+		normalize:  teetypes.NormalizeLinkedInSlug,
+		required:   ErrLinkedInCompanyRequired,
+		urlInvalid: ErrLinkedInCompanyURLInvalid,
+		wrongKind:  ErrLinkedInNotCompanyURL,
+		idInvalid:  ErrLinkedInCompanySlugInvalid,
+		mismatch:   ErrLinkedInCompanyMismatch,
+	}
+)
+
+type linkedInCandidate struct{ field, value string }
+
+// resolve returns the canonical identifier of the target, taken from the first non-empty candidate. Any of them can
+// hold either an identifier or a URL. The last candidate is a fallback (the query) and is ignored if another one is set.
+func (t linkedInTarget) resolve(candidates ...linkedInCandidate) (string, error) {
+	var ret, retField string
+	for i, candidate := range candidates {
+		// Query is only a fallback, it may hold unrelated keywords
+		if strings.TrimSpace(candidate.value) == "" || (i == len(candidates)-1 && ret != "") {
+			continue
+		}
+
+		id, err := t.parse(candidate.field, candidate.value)
+		if err != nil {
+			return "", err
+		}
+
+		if ret == "" {
+			ret, retField = id, candidate.field
+		} else if id != ret {
+			return "", NewValidationError(candidate.field, CodeInvalidValue, candidate.value,
+				fmt.Errorf("%w: %s is %q, %s is %q", t.mismatch, retField, ret, candidate.field, id))
+		}
+	}
+
+	if ret == "" {
+		return "", NewValidationError(candidates[0].field, CodeRequired, nil, t.required)
+	}
+	return ret, nil
+}
+
+// parse extracts the canonical identifier from a URL or a bare identifier
+func (t linkedInTarget) parse(field, value string) (string, error) {
+	if !strings.Contains(value, "linkedin.com") && !strings.Contains(value, "://") {
+		id, ok := t.normalize(value)
+		if !ok {
+			return "", NewValidationError(field, CodeInvalidFormat, value, fmt.Errorf("%w, got: %s", t.idInvalid, value))
+		}
+		return id, nil
+	}
+
+	u, err := teetypes.ParseLinkedInURL(value)
+	if err != nil {
+		return "", NewValidationError(field, CodeInvalidFormat, value, fmt.Errorf("%w: %w", t.urlInvalid, err))
+	}
+	if err := checkURLPolicy(field, u.Raw); err != nil {
+		return "", err
+	}
+	if u.Kind != t.kind {
+		return "", NewValidationError(field, CodeInvalidValue, value, fmt.Errorf("%w, got a %s URL: %s", t.wrongKind, u.Kind, value))
+	}
+	if t.kind == teetypes.LinkedInProfileURL {
+		return u.PublicIdentifier, nil
+	}
+	return u.Slug, nil
+}
+
+// validateJobFilters checks the job search filters, which are only allowed for searchjobs
+func (l *LinkedInArguments) validateJobFilters() []error {
+	var errs []error
+
+	if !l.IsJobSearchOperation() {
+		for _, filter := range []struct {
+			field string
+			set   bool
+		}{
+			{"location", l.Location != ""},
+			{"workplace_types", len(l.WorkplaceTypes) > 0},
+			{"experience_levels", len(l.ExperienceLevels) > 0},
+			{"date_posted", l.DatePosted != ""},
+			{"companies", len(l.Companies) > 0},
+		} {
+			if filter.set {
+				errs = append(errs, NewValidationError(filter.field, CodeNotAllowed, nil, ErrLinkedInJobFilterNotAllowed))
+			}
+		}
+		return errs
 	}
 
-	// Validate QueryType against job-specific capabilities
-	return jobType.ValidateCapability(teetypes.Capability(l.QueryType))
+	if strings.TrimSpace(l.Query) == "" && strings.TrimSpace(l.Location) == "" && len(l.Companies) == 0 {
+		errs = append(errs, NewValidationError("query", CodeRequired, nil, ErrLinkedInJobSearchRequired))
+	}
+
+	for i, w := range l.WorkplaceTypes {
+		if !teetypes.AllLinkedInWorkplaceTypes.Contains(w) {
+			errs = append(errs, NewValidationError(fmt.Sprintf("workplace_types[%d]", i), CodeInvalidValue, w, fmt.Errorf("%w: %s", ErrLinkedInInvalidWorkplace, w)))
+		}
+	}
+
+	for i, e := range l.ExperienceLevels {
+		if !teetypes.AllLinkedInExperienceLevels.Contains(e) {
+			errs = append(errs, NewValidationError(fmt.Sprintf("experience_levels[%d]", i), CodeInvalidValue, e, fmt.Errorf("%w: %s", ErrLinkedInInvalidExperience, e)))
+		}
+	}
+
+	if l.DatePosted != "" && !teetypes.AllLinkedInDatePosted.Contains(l.DatePosted) {
+		errs = append(errs, NewValidationError("date_posted", CodeInvalidValue, l.DatePosted, fmt.Errorf("%w: %s", ErrLinkedInInvalidDatePosted, l.DatePosted)))
+	}
+
+	for i, company := range l.Companies {
+		if _, err := linkedInCompanyTarget.parse(fmt.Sprintf("companies[%d]", i), company); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// resolvePublicIdentifier returns the canonical public identifier of the requested profile, taken from
+// PublicIdentifier, ProfileURL or, for backward compatibility, Query
+func (l *LinkedInArguments) resolvePublicIdentifier() (string, error) {
+	return linkedInProfileTarget.resolve(
+		linkedInCandidate{"public_identifier", l.PublicIdentifier},
+		linkedInCandidate{"profile_url", l.ProfileURL},
+		linkedInCandidate{"query", l.Query},
+	)
+}
+
+// resolveCompanySlug returns the canonical slug of the requested company, taken from CompanySlug, CompanyURL or Query
+func (l *LinkedInArguments) resolveCompanySlug() (string, error) {
+	return linkedInCompanyTarget.resolve(
+		linkedInCandidate{"company_slug", l.CompanySlug},
+		linkedInCandidate{"company_url", l.CompanyURL},
+		linkedInCandidate{"query", l.Query},
+	)
+}
+
+// ValidateForJobType validates LinkedIn arguments for a specific job type
+func (l *LinkedInArguments) ValidateForJobType(jobType teetypes.JobType) error {
+	// Validate QueryType against job-specific capabilities, reporting it along with any field errors
+	return errors.Join(l.Validate(), validateCapability(jobType, teetypes.Capability(l.QueryType)))
 }
 
 // GetCapability returns the QueryType as a typed Capability
@@ -70,6 +279,26 @@ func (l *LinkedInArguments) GetCapability() teetypes.Capability {
 	return teetypes.Capability(l.QueryType)
 }
 
+// AnnotateSchema adds the LinkedIn validation rules to the generated JSON Schema
+func (l *LinkedInArguments) AnnotateSchema(capability teetypes.Capability, schema *JSONSchema) {
+	if capability == teetypes.CapGetProfile {
+		schema.Property("public_identifier").Description = "Profile identifier or URL, required unless profile_url is set"
+		schema.Property("profile_url").Format = "uri"
+	}
+	if capability == teetypes.CapSearchJobs {
+		schema.Property("workplace_types").Items.SetEnum(enumFromSet(teetypes.AllLinkedInWorkplaceTypes)...)
+		schema.Property("experience_levels").Items.SetEnum(enumFromSet(teetypes.AllLinkedInExperienceLevels)...)
+		schema.Property("date_posted").SetEnum(enumFromSet(teetypes.AllLinkedInDatePosted)...).SetDefault(teetypes.LinkedInPostedAnyTime)
+		schema.Property("companies").Items.Description = "Company slug or URL"
+	}
+	if capability == teetypes.CapGetCompany {
+		schema.Property("company_slug").Description = "Company slug or URL, required unless company_url is set"
+		schema.Property("company_url").Format = "uri"
+	}
+	schema.Property("max_results").SetMinimum(0)
+	schema.Property("start").SetMinimum(0)
+}
+
 // IsSearchOperation returns true if this is a search operation
 func (l *LinkedInArguments) IsSearchOperation() bool {
 	capability := l.GetCapability()
@@ -82,6 +311,43 @@ func (l *LinkedInArguments) IsProfileOperation() bool {
 	return capability == teetypes.CapGetProfile
 }
 
+// IsCompanyOperation returns true if this is a company page operation
+func (l *LinkedInArguments) IsCompanyOperation() bool {
+	capability := l.GetCapability()
+	return capability == teetypes.CapGetCompany
+}
+
+// IsJobSearchOperation returns true if this is a job postings search
+func (l *LinkedInArguments) IsJobSearchOperation() bool {
+	capability := l.GetCapability()
+	return capability == teetypes.CapSearchJobs
+}
+
+// GetCompanyFilter returns the canonical slugs of the companies in the job search filter, skipping invalid ones
+func (l *LinkedInArguments) GetCompanyFilter() []string {
+	var ret []string
+	for _, company := range l.Companies {
+		if slug, err := linkedInCompanyTarget.parse("companies", company); err == nil {
+			ret = append(ret, slug)
+		}
+	}
+	return ret
+}
+
+// GetPublicIdentifier returns the canonical public identifier of the profile requested by getprofile, extracted from
+// PublicIdentifier, ProfileURL or Query. It returns an empty string if none of them holds a valid profile.
+func (l *LinkedInArguments) GetPublicIdentifier() string {
+	id, _ := l.resolvePublicIdentifier()
+	return id
+}
+
+// GetCompanySlug returns the canonical slug of the company requested by getcompany, extracted from CompanySlug,
+// CompanyURL or Query. It returns an empty string if none of them holds a valid company.
+func (l *LinkedInArguments) GetCompanySlug() string {
+	slug, _ := l.resolveCompanySlug()
+	return slug
+}
+
 // HasNetworkFilters returns true if network filters are specified
 func (l *LinkedInArguments) HasNetworkFilters() bool {
 	return len(l.NetworkFilters) > 0
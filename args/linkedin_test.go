@@ -0,0 +1,188 @@
+package args_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("LinkedInArguments", func() {
+	Describe("getprofile", func() {
+		It("should extract the identifier from a profile URL", func() {
+			var linkedInArgs args.LinkedInArguments
+			jsonData := []byte(`{"type":"getprofile","profile_url":"https://uk.linkedin.com/in/John-Doe-123/?originalSubdomain=uk"}`)
+			Expect(json.Unmarshal(jsonData, &linkedInArgs)).To(Succeed())
+			Expect(linkedInArgs.GetPublicIdentifier()).To(Equal("john-doe-123"))
+		})
+
+		It("should accept a URL or a bare identifier in public_identifier", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetProfile), PublicIdentifier: "https://www.linkedin.com/in/jdoe/details/skills/"}
+			Expect(linkedInArgs.Validate()).To(Succeed())
+			Expect(linkedInArgs.GetPublicIdentifier()).To(Equal("jdoe"))
+
+			linkedInArgs.PublicIdentifier = "JDoe"
+			linkedInArgs.ProfileURL = "https://www.linkedin.com/in/jdoe"
+			Expect(linkedInArgs.Validate()).To(Succeed())
+			Expect(linkedInArgs.GetPublicIdentifier()).To(Equal("jdoe"))
+		})
+
+		It("should fall back to the query", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetProfile), Query: "jdoe"}
+			Expect(linkedInArgs.Validate()).To(Succeed())
+			Expect(linkedInArgs.GetPublicIdentifier()).To(Equal("jdoe"))
+		})
+
+		It("should require an identifier or a URL", func() {
+			var linkedInArgs args.LinkedInArguments
+			err := json.Unmarshal([]byte(`{"type":"getprofile"}`), &linkedInArgs)
+			Expect(errors.Is(err, args.ErrLinkedInProfileRequired)).To(BeTrue())
+			Expect(args.ValidationErrors(err)[0].Code).To(Equal(args.CodeRequired))
+		})
+
+		It("should reject company and school URLs", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetProfile), ProfileURL: "https://www.linkedin.com/company/masa/"}
+			err := linkedInArgs.Validate()
+			Expect(errors.Is(err, args.ErrLinkedInNotProfileURL)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("company")))
+			Expect(args.ValidationErrors(err)[0].Field).To(Equal("profile_url"))
+		})
+
+		It("should reject malformed identifiers and URLs", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetProfile), PublicIdentifier: "john doe"}
+			Expect(errors.Is(linkedInArgs.Validate(), args.ErrLinkedInIdentifierInvalid)).To(BeTrue())
+
+			linkedInArgs = &args.LinkedInArguments{QueryType: string(types.CapGetProfile), ProfileURL: "https://example.com/in/jdoe"}
+			Expect(errors.Is(linkedInArgs.Validate(), args.ErrLinkedInProfileURLInvalid)).To(BeTrue())
+		})
+
+		It("should reject conflicting identifiers", func() {
+			linkedInArgs := &args.LinkedInArguments{
+				QueryType:        string(types.CapGetProfile),
+				PublicIdentifier: "jdoe",
+				ProfileURL:       "https://www.linkedin.com/in/someone-else/",
+			}
+			Expect(errors.Is(linkedInArgs.Validate(), args.ErrLinkedInProfileMismatch)).To(BeTrue())
+		})
+
+		It("should not require an identifier for searches", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapSearchByQuery), Query: "software engineer"}
+			Expect(linkedInArgs.Validate()).To(Succeed())
+		})
+	})
+
+	Describe("getcompany", func() {
+		It("should be registered for the LinkedIn job type", func() {
+			jobArgs, err := args.UnmarshalJobArguments(types.LinkedInJob, map[string]any{
+				"type":        "getcompany",
+				"company_url": "https://www.linkedin.com/company/Masa-Finance/about/",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			linkedInArgs, ok := jobArgs.(*args.LinkedInArguments)
+			Expect(ok).To(BeTrue())
+			Expect(linkedInArgs.IsCompanyOperation()).To(BeTrue())
+			Expect(linkedInArgs.GetCompanySlug()).To(Equal("masa-finance"))
+		})
+
+		It("should accept a slug in company_slug or query", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetCompany), CompanySlug: "Microsoft"}
+			Expect(linkedInArgs.ValidateForJobType(types.LinkedInJob)).To(Succeed())
+			Expect(linkedInArgs.GetCompanySlug()).To(Equal("microsoft"))
+
+			linkedInArgs = &args.LinkedInArguments{QueryType: string(types.CapGetCompany), Query: "microsoft"}
+			Expect(linkedInArgs.Validate()).To(Succeed())
+			Expect(linkedInArgs.GetCompanySlug()).To(Equal("microsoft"))
+		})
+
+		It("should require a slug or a URL", func() {
+			_, err := args.UnmarshalJobArguments(types.LinkedInJob, map[string]any{"type": "getcompany"})
+			Expect(errors.Is(err, args.ErrLinkedInCompanyRequired)).To(BeTrue())
+		})
+
+		It("should reject profile URLs", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetCompany), CompanyURL: "https://www.linkedin.com/in/jdoe/"}
+			err := linkedInArgs.Validate()
+			Expect(errors.Is(err, args.ErrLinkedInNotCompanyURL)).To(BeTrue())
+			Expect(args.ValidationErrors(err)[0].Field).To(Equal("company_url"))
+		})
+
+		It("should default LinkedIn jobs to searchbyquery", func() {
+			jobArgs, err := args.UnmarshalJobArguments(types.LinkedInJob, map[string]any{"query": "software engineer"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(jobArgs.GetCapability()).To(Equal(types.CapSearchByQuery))
+		})
+	})
+
+	Describe("searchjobs", func() {
+		It("should accept and normalize the job filters", func() {
+			jobArgs, err := args.UnmarshalJobArguments(types.LinkedInJob, map[string]any{
+				"type":              "searchjobs",
+				"query":             "golang engineer",
+				"location":          "London, United Kingdom",
+				"workplace_types":   []string{"Remote", "hybrid"},
+				"experience_levels": []string{"mid_senior"},
+				"date_posted":       "PAST_WEEK",
+				"companies":         []string{"Microsoft", "https://www.linkedin.com/company/masa-finance/"},
+				"max_results":       25,
+				"start":             25,
+			})
+			Expect(err).NotTo(HaveOccurred())
+			linkedInArgs := jobArgs.(*args.LinkedInArguments)
+			Expect(linkedInArgs.IsJobSearchOperation()).To(BeTrue())
+			Expect(linkedInArgs.WorkplaceTypes).To(Equal([]types.LinkedInWorkplaceType{types.LinkedInWorkplaceRemote, types.LinkedInWorkplaceHybrid}))
+			Expect(linkedInArgs.DatePosted).To(Equal(types.LinkedInPostedPastWeek))
+			Expect(linkedInArgs.GetCompanyFilter()).To(Equal([]string{"microsoft", "masa-finance"}))
+			Expect(linkedInArgs.GetEffectiveMaxResults()).To(Equal(25))
+		})
+
+		It("should require a query, a location or a company", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapSearchJobs)}
+			Expect(errors.Is(linkedInArgs.Validate(), args.ErrLinkedInJobSearchRequired)).To(BeTrue())
+
+			linkedInArgs.Location = "Berlin"
+			Expect(linkedInArgs.Validate()).To(Succeed())
+		})
+
+		It("should report every invalid filter", func() {
+			linkedInArgs := &args.LinkedInArguments{
+				QueryType:        string(types.CapSearchJobs),
+				Query:            "golang",
+				WorkplaceTypes:   []types.LinkedInWorkplaceType{"remote", "moon"},
+				ExperienceLevels: []types.LinkedInExperienceLevel{"guru"},
+				DatePosted:       "yesterday",
+				Companies:        []string{"https://www.linkedin.com/in/jdoe/"},
+				Start:            -1,
+			}
+			err := linkedInArgs.Validate()
+			Expect(errors.Is(err, args.ErrLinkedInInvalidWorkplace)).To(BeTrue())
+			Expect(errors.Is(err, args.ErrLinkedInInvalidExperience)).To(BeTrue())
+			Expect(errors.Is(err, args.ErrLinkedInInvalidDatePosted)).To(BeTrue())
+			Expect(errors.Is(err, args.ErrLinkedInNotCompanyURL)).To(BeTrue())
+			Expect(errors.Is(err, args.ErrLinkedInStartNegative)).To(BeTrue())
+
+			var fields []string
+			for _, ve := range args.ValidationErrors(err) {
+				fields = append(fields, ve.Field)
+			}
+			Expect(fields).To(ConsistOf("start", "workplace_types[1]", "experience_levels[0]", "date_posted", "companies[0]"))
+		})
+
+		It("should reject job filters for other capabilities", func() {
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapSearchByQuery), Query: "golang", Location: "Berlin"}
+			err := linkedInArgs.Validate()
+			Expect(errors.Is(err, args.ErrLinkedInJobFilterNotAllowed)).To(BeTrue())
+			Expect(args.ValidationErrors(err)[0].Code).To(Equal(args.CodeNotAllowed))
+		})
+
+		It("should describe the filters in the schema", func() {
+			schema, err := args.GenerateSchema(types.LinkedInJob, types.CapSearchJobs)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schema.Property("workplace_types").Items.Enum).To(ConsistOf(types.LinkedInWorkplaceHybrid, types.LinkedInWorkplaceOnsite, types.LinkedInWorkplaceRemote))
+			Expect(schema.Property("date_posted").Default).To(Equal(types.LinkedInPostedAnyTime))
+		})
+	})
+})
@@ -5,13 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 
 	teetypes "github.com/masa-finance/tee-types/types"
 )
 
 var (
-	ErrLLMDatasetIdRequired = errors.New("dataset id is required")
-	ErrLLMPromptRequired    = errors.New("prompt is required")
+	ErrLLMDatasetIdRequired = errors.New("is required")
+	ErrLLMPromptRequired    = errors.New("is required")
 )
 
 const (
@@ -23,6 +24,7 @@ const (
 )
 
 type LLMProcessorArguments struct {
+	QueryType   string  `json:"type"` // Optional, defaults to the dataset processor
 	DatasetId   string  `json:"dataset_id"`
 	Prompt      string  `json:"prompt"`
 	MaxTokens   uint    `json:"max_tokens"`
@@ -44,6 +46,9 @@ func (l *LLMProcessorArguments) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal llm arguments: %w", err)
 	}
 
+	// Normalize QueryType to lowercase
+	l.QueryType = strings.ToLower(l.QueryType)
+
 	l.setDefaultValues()
 
 	return l.Validate()
@@ -62,13 +67,28 @@ func (l *LLMProcessorArguments) setDefaultValues() {
 }
 
 func (l *LLMProcessorArguments) Validate() error {
+	var errs []error
 	if l.DatasetId == "" {
-		return ErrLLMDatasetIdRequired
+		errs = append(errs, NewValidationError("dataset_id", CodeRequired, nil, ErrLLMDatasetIdRequired))
 	}
 	if l.Prompt == "" {
-		return ErrLLMPromptRequired
+		errs = append(errs, NewValidationError("prompt", CodeRequired, nil, ErrLLMPromptRequired))
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateForJobType validates LLM arguments for a specific job type
+func (l *LLMProcessorArguments) ValidateForJobType(jobType teetypes.JobType) error {
+	// Validate QueryType against job-specific capabilities, reporting it along with any field errors
+	return errors.Join(l.Validate(), validateCapability(jobType, l.GetCapability()))
+}
+
+// GetCapability returns the QueryType as a typed Capability, or the dataset processor if it is empty
+func (l *LLMProcessorArguments) GetCapability() teetypes.Capability {
+	if l.QueryType == "" {
+		return teetypes.CapDatasetProcessor
 	}
-	return nil
+	return teetypes.Capability(l.QueryType)
 }
 
 func (l LLMProcessorArguments) ToLLMProcessorRequest() teetypes.LLMProcessorRequest {
@@ -81,3 +101,11 @@ func (l LLMProcessorArguments) ToLLMProcessorRequest() teetypes.LLMProcessorRequ
 		Model:           LLMDefaultModel,           // overrides default in actor API
 	}
 }
+
+// AnnotateSchema adds the LLM processor validation rules and defaults to the generated JSON Schema
+func (l *LLMProcessorArguments) AnnotateSchema(_ teetypes.Capability, schema *JSONSchema) {
+	schema.SetRequired("dataset_id", "prompt")
+	schema.Property("max_tokens").SetDefault(LLMDefaultMaxTokens)
+	schema.Property("temperature").SetDefault(LLMDefaultTemperature)
+	schema.Property("items").SetDefault(LLMDefaultItems)
+}
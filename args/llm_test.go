@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
 )
 
 var _ = Describe("LLMProcessorArguments", func() {
@@ -92,6 +93,37 @@ var _ = Describe("LLMProcessorArguments", func() {
 		})
 	})
 
+	Describe("Job capability", func() {
+		It("should return the dataset processor capability", func() {
+			llmArgs := &args.LLMProcessorArguments{}
+			Expect(llmArgs.GetCapability()).To(Equal(types.CapDatasetProcessor))
+		})
+
+		It("should validate capability for LLMJob", func() {
+			llmArgs := &args.LLMProcessorArguments{
+				QueryType: string(types.CapDatasetProcessor),
+				DatasetId: "ds1",
+				Prompt:    "p",
+			}
+			Expect(llmArgs.ValidateForJobType(types.LLMJob)).To(Succeed())
+			Expect(llmArgs.ValidateForJobType(types.WebJob)).ToNot(Succeed())
+		})
+
+		It("should normalize the query type", func() {
+			var llmArgs args.LLMProcessorArguments
+			Expect(json.Unmarshal([]byte(`{"type":"DatasetProcessor","dataset_id":"ds1","prompt":"p"}`), &llmArgs)).To(Succeed())
+			Expect(llmArgs.GetCapability()).To(Equal(types.CapDatasetProcessor))
+			Expect(llmArgs.ValidateForJobType(types.LLMJob)).To(Succeed())
+		})
+
+		It("should reject an unknown query type", func() {
+			var llmArgs args.LLMProcessorArguments
+			Expect(json.Unmarshal([]byte(`{"type":"bogus","dataset_id":"ds1","prompt":"p"}`), &llmArgs)).To(Succeed())
+			Expect(llmArgs.GetCapability()).To(Equal(types.Capability("bogus")))
+			Expect(llmArgs.ValidateForJobType(types.LLMJob)).ToNot(Succeed())
+		})
+	})
+
 	Describe("ToLLMProcessorRequest", func() {
 		It("should map request fields to actor request fields", func() {
 			llmArgs := args.LLMProcessorArguments{
@@ -12,13 +12,14 @@ import (
 )
 
 var (
-	ErrRedditInvalidType       = errors.New("invalid type")
-	ErrRedditInvalidSort       = errors.New("invalid sort")
-	ErrRedditTimeInTheFuture   = errors.New("after field is in the future")
-	ErrRedditNoQueries         = errors.New("queries must be provided for all query types except scrapeurls")
-	ErrRedditNoUrls            = errors.New("urls must be provided for scrapeurls query type")
-	ErrRedditQueriesNotAllowed = errors.New("the scrapeurls query type does not admit queries")
-	ErrRedditUrlsNotAllowed    = errors.New("urls can only be provided for the scrapeurls query type")
+	ErrRedditInvalidType       = errors.New("unknown query type")
+	ErrRedditInvalidSort       = errors.New("unknown sort order")
+	ErrRedditInvalidTime       = errors.New("unknown time period")
+	ErrRedditTimeInTheFuture   = errors.New("is in the future")
+	ErrRedditNoQueries         = errors.New("required for all query types except scrapeurls")
+	ErrRedditNoUrls            = errors.New("required for the scrapeurls query type")
+	ErrRedditQueriesNotAllowed = errors.New("not allowed for the scrapeurls query type")
+	ErrRedditUrlsNotAllowed    = errors.New("only allowed for the scrapeurls query type")
 )
 
 const (
@@ -29,9 +30,13 @@ const (
 	redditDefaultMaxCommunities = 2
 	redditDefaultMaxUsers       = 2
 	redditDefaultSort           = teetypes.RedditSortNew
+	redditDefaultTime           = teetypes.RedditTimeAll
 )
 
-const redditDomainSuffix = "reddit.com"
+const (
+	redditDomainSuffix  = "reddit.com"
+	redditDefaultMethod = "GET"
+)
 
 // RedditArguments defines args for Reddit scrapes
 // see https://apify.com/trudax/reddit-scraper
@@ -40,8 +45,10 @@ type RedditArguments struct {
 	Queries        []string                 `json:"queries"`
 	URLs           []string                 `json:"urls"`
 	Sort           teetypes.RedditSortType  `json:"sort"`
+	Time           teetypes.RedditTime      `json:"time"` // The period ranked by the top sort, default all
 	IncludeNSFW    bool                     `json:"include_nsfw"`
 	SkipPosts      bool                     `json:"skip_posts"`      // Valid only for searchusers
+	SkipComments   bool                     `json:"skip_comments"`   // Don't scrape the comments of the posts
 	After          time.Time                `json:"after"`           // valid only for scrapeurls and searchposts
 	MaxItems       uint                     `json:"max_items"`       // Max number of items to scrape (total), default 10
 	MaxResults     uint                     `json:"max_results"`     // Max number of results per page, default MaxItems
@@ -93,24 +100,32 @@ func (r *RedditArguments) setDefaultValues() {
 	if r.Sort == "" {
 		r.Sort = redditDefaultSort
 	}
+	if r.Time == "" {
+		r.Time = redditDefaultTime
+	}
 
 	r.QueryType = teetypes.RedditQueryType(strings.ToLower(string(r.QueryType)))
 	r.Sort = teetypes.RedditSortType(strings.ToLower(string(r.Sort)))
+	r.Time = teetypes.RedditTime(strings.ToLower(string(r.Time)))
 }
 
 func (r *RedditArguments) Validate() error {
 	var errs []error
 
 	if !teetypes.AllRedditQueryTypes.Contains(r.QueryType) {
-		errs = append(errs, ErrRedditInvalidType)
+		errs = append(errs, NewValidationError("type", CodeInvalidValue, r.QueryType, ErrRedditInvalidType))
 	}
 
 	if !teetypes.AllRedditSortTypes.Contains(r.Sort) {
-		errs = append(errs, ErrRedditInvalidSort)
+		errs = append(errs, NewValidationError("sort", CodeInvalidValue, r.Sort, ErrRedditInvalidSort))
+	}
+
+	if r.Time != "" && !teetypes.AllRedditTimes.Contains(r.Time) {
+		errs = append(errs, NewValidationError("time", CodeInvalidValue, r.Time, ErrRedditInvalidTime))
 	}
 
 	if time.Now().Before(r.After) {
-		errs = append(errs, ErrRedditTimeInTheFuture)
+		errs = append(errs, NewValidationError("after", CodeOutOfRange, r.After, ErrRedditTimeInTheFuture))
 	}
 
 	if len(errs) > 0 {
@@ -119,34 +134,37 @@ func (r *RedditArguments) Validate() error {
 
 	if r.QueryType == teetypes.RedditScrapeUrls {
 		if len(r.URLs) == 0 {
-			errs = append(errs, ErrRedditNoUrls)
+			errs = append(errs, NewValidationError("urls", CodeRequired, nil, ErrRedditNoUrls))
 		}
 		if len(r.Queries) > 0 {
-			errs = append(errs, ErrRedditQueriesNotAllowed)
+			errs = append(errs, NewValidationError("queries", CodeNotAllowed, r.Queries, ErrRedditQueriesNotAllowed))
 		}
 
-		for _, u := range r.URLs {
-			u, err := url.Parse(u)
+		for i, rawURL := range r.URLs {
+			field := fmt.Sprintf("urls[%d]", i)
+			u, err := url.Parse(rawURL)
 			if err != nil {
-				errs = append(errs, fmt.Errorf("%s is not a valid URL", u))
+				errs = append(errs, NewValidationError(field, CodeInvalidFormat, rawURL, fmt.Errorf("%s is not a valid URL", rawURL)))
+			} else if err := checkURLPolicy(field, rawURL); err != nil {
+				errs = append(errs, err)
 			} else {
 				if !strings.HasSuffix(strings.ToLower(u.Host), redditDomainSuffix) {
-					errs = append(errs, fmt.Errorf("invalid Reddit URL %s", u))
+					errs = append(errs, NewValidationError(field, CodeInvalidValue, rawURL, fmt.Errorf("invalid Reddit URL %s", u)))
 				}
 				if !strings.HasPrefix(u.Path, "/r/") {
-					errs = append(errs, fmt.Errorf("%s is not a Reddit post or comment URL (missing /r/)", u))
+					errs = append(errs, NewValidationError(field, CodeInvalidValue, rawURL, fmt.Errorf("%s is not a Reddit post or comment URL (missing /r/)", u)))
 				}
 				if !strings.Contains(u.Path, "/comments/") {
-					errs = append(errs, fmt.Errorf("%s is not a Reddit post or comment URL (missing /comments/)", u))
+					errs = append(errs, NewValidationError(field, CodeInvalidValue, rawURL, fmt.Errorf("%s is not a Reddit post or comment URL (missing /comments/)", u)))
 				}
 			}
 		}
 	} else {
 		if len(r.Queries) == 0 {
-			errs = append(errs, ErrRedditNoQueries)
+			errs = append(errs, NewValidationError("queries", CodeRequired, nil, ErrRedditNoQueries))
 		}
 		if len(r.URLs) > 0 {
-			errs = append(errs, ErrRedditUrlsNotAllowed)
+			errs = append(errs, NewValidationError("urls", CodeNotAllowed, r.URLs, ErrRedditUrlsNotAllowed))
 		}
 	}
 
@@ -155,15 +173,77 @@ func (r *RedditArguments) Validate() error {
 
 // ValidateForJobType validates Twitter arguments for a specific job type
 func (r *RedditArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := r.Validate(); err != nil {
-		return err
-	}
-
-	// Validate QueryType against job-specific capabilities
-	return jobType.ValidateCapability(teetypes.Capability(r.QueryType))
+	// Validate QueryType against job-specific capabilities, reporting it along with any field errors
+	return errors.Join(r.Validate(), validateCapability(jobType, teetypes.Capability(r.QueryType)))
 }
 
 // GetCapability returns the QueryType as a typed Capability
 func (r *RedditArguments) GetCapability() teetypes.Capability {
 	return teetypes.Capability(r.QueryType)
 }
+
+// ToRedditScraperRequest builds the Apify Reddit scraper input for the arguments
+func (r RedditArguments) ToRedditScraperRequest() teetypes.RedditScraperRequest {
+	req := teetypes.RedditScraperRequest{
+		Sort:                r.Sort,
+		Time:                r.Time,
+		IncludeNSFW:         r.IncludeNSFW,
+		SkipComments:        r.SkipComments,
+		MaxItems:            r.MaxItems,
+		MaxPostCount:        r.MaxPosts,
+		MaxComments:         r.MaxComments,
+		MaxCommunitiesCount: r.MaxCommunities,
+		MaxUserCount:        r.MaxUsers,
+		MaxResults:          r.MaxResults,
+	}
+
+	switch r.QueryType {
+	case teetypes.RedditScrapeUrls:
+		req.StartUrls = make([]teetypes.RedditStartURL, len(r.URLs))
+		for i, u := range r.URLs {
+			req.StartUrls[i] = teetypes.RedditStartURL{URL: u, Method: redditDefaultMethod}
+		}
+	case teetypes.RedditSearchPosts:
+		req.Searches = r.Queries
+		req.SearchPosts = true
+	case teetypes.RedditSearchUsers:
+		req.Searches = r.Queries
+		req.SearchUsers = true
+		req.SkipUserPosts = r.SkipPosts
+	case teetypes.RedditSearchCommunities:
+		req.Searches = r.Queries
+		req.SearchCommunities = true
+	}
+
+	// After is only honoured by the actor when scraping URLs or searching posts
+	if !r.After.IsZero() && (r.QueryType == teetypes.RedditScrapeUrls || r.QueryType == teetypes.RedditSearchPosts) {
+		after := r.After.UTC()
+		req.PostDateLimit = &after
+	}
+
+	return req
+}
+
+// AnnotateSchema adds the Reddit validation rules and defaults to the generated JSON Schema
+func (r *RedditArguments) AnnotateSchema(capability teetypes.Capability, schema *JSONSchema) {
+	schema.Property("type").SetEnum(enumFromSet(teetypes.AllRedditQueryTypes)...)
+	schema.Property("sort").SetEnum(enumFromSet(teetypes.AllRedditSortTypes)...).SetDefault(redditDefaultSort)
+	schema.Property("time").SetEnum(enumFromSet(teetypes.AllRedditTimes)...).SetDefault(redditDefaultTime)
+	schema.Property("max_items").SetDefault(redditDefaultMaxItems)
+	schema.Property("max_posts").SetDefault(redditDefaultMaxPosts)
+	schema.Property("max_comments").SetDefault(redditDefaultMaxComments)
+	schema.Property("max_communities").SetDefault(redditDefaultMaxCommunities)
+	schema.Property("max_users").SetDefault(redditDefaultMaxUsers)
+
+	switch teetypes.RedditQueryType(capability) {
+	case "":
+		// The required fields depend on the capability
+	case teetypes.RedditScrapeUrls:
+		schema.SetRequired("urls")
+		schema.Property("urls").SetMinItems(1)
+		schema.Property("urls").Items.Format = "uri"
+	default:
+		schema.SetRequired("queries")
+		schema.Property("queries").SetMinItems(1)
+	}
+}
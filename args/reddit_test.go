@@ -28,6 +28,7 @@ var _ = Describe("RedditArguments", func() {
 			Expect(redditArgs.MaxCommunities).To(Equal(uint(2)))
 			Expect(redditArgs.MaxUsers).To(Equal(uint(2)))
 			Expect(redditArgs.Sort).To(Equal(types.RedditSortNew))
+			Expect(redditArgs.Time).To(Equal(types.RedditTimeAll))
 			Expect(redditArgs.MaxResults).To(Equal(redditArgs.MaxItems))
 		})
 
@@ -98,6 +99,15 @@ var _ = Describe("RedditArguments", func() {
 			Expect(err).To(MatchError(args.ErrRedditInvalidSort))
 		})
 
+		It("should fail with an invalid time", func() {
+			var redditArgs args.RedditArguments
+			err := json.Unmarshal([]byte(`{"type":"searchposts","queries":["test"],"sort":"top","time":"decade"}`), &redditArgs)
+			Expect(err).To(MatchError(args.ErrRedditInvalidTime))
+
+			Expect(json.Unmarshal([]byte(`{"type":"searchposts","queries":["test"],"sort":"top","time":"WEEK"}`), &redditArgs)).To(Succeed())
+			Expect(redditArgs.Time).To(Equal(types.RedditTimeWeek))
+		})
+
 		It("should fail if the after time is in the future", func() {
 			redditArgs := &args.RedditArguments{
 				QueryType: types.RedditSearchPosts,
@@ -182,4 +192,80 @@ var _ = Describe("RedditArguments", func() {
 			Expect(err.Error()).To(ContainSubstring("not a Reddit post or comment URL"))
 		})
 	})
+
+	Describe("ToRedditScraperRequest", func() {
+		It("should map scrapeurls to start URLs", func() {
+			after := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+			redditArgs := args.RedditArguments{
+				QueryType:    types.RedditScrapeUrls,
+				URLs:         []string{"https://www.reddit.com/r/golang/comments/foo/bar"},
+				Sort:         types.RedditSortTop,
+				Time:         types.RedditTimeMonth,
+				IncludeNSFW:  true,
+				After:        after,
+				SkipComments: true,
+				MaxItems:     5,
+				MaxResults:   4,
+				MaxPosts:     6,
+				MaxComments:  7,
+			}
+			req := redditArgs.ToRedditScraperRequest()
+			Expect(req.StartUrls).To(Equal([]types.RedditStartURL{{URL: "https://www.reddit.com/r/golang/comments/foo/bar", Method: "GET"}}))
+			Expect(req.Searches).To(BeEmpty())
+			Expect(req.SearchPosts || req.SearchUsers || req.SearchCommunities || req.SearchComments).To(BeFalse())
+			Expect(req.Sort).To(Equal(types.RedditSortTop))
+			Expect(req.Time).To(Equal(types.RedditTimeMonth))
+			Expect(req.IncludeNSFW).To(BeTrue())
+			Expect(req.SkipComments).To(BeTrue())
+			Expect(*req.PostDateLimit).To(Equal(after))
+			Expect(req.MaxItems).To(Equal(uint(5)))
+			Expect(req.MaxResults).To(Equal(uint(4)))
+			Expect(req.MaxPostCount).To(Equal(uint(6)))
+			Expect(req.MaxComments).To(Equal(uint(7)))
+		})
+
+		It("should map the search query types to the search flags", func() {
+			redditArgs := args.RedditArguments{
+				QueryType: types.RedditSearchUsers,
+				Queries:   []string{"zaphod"},
+				SkipPosts: true,
+				After:     time.Now().Add(-time.Hour),
+				MaxUsers:  3,
+			}
+			req := redditArgs.ToRedditScraperRequest()
+			Expect(req.Searches).To(Equal([]string{"zaphod"}))
+			Expect(req.SearchUsers).To(BeTrue())
+			Expect(req.SearchPosts).To(BeFalse())
+			Expect(req.SkipUserPosts).To(BeTrue())
+			Expect(req.PostDateLimit).To(BeNil())
+			Expect(req.MaxUserCount).To(Equal(uint(3)))
+
+			redditArgs.QueryType = types.RedditSearchPosts
+			req = redditArgs.ToRedditScraperRequest()
+			Expect(req.SearchPosts).To(BeTrue())
+			Expect(req.SkipUserPosts).To(BeFalse())
+			Expect(req.PostDateLimit).ToNot(BeNil())
+
+			redditArgs.QueryType = types.RedditSearchCommunities
+			req = redditArgs.ToRedditScraperRequest()
+			Expect(req.SearchCommunities).To(BeTrue())
+		})
+
+		It("should carry the defaults through unmarshalling", func() {
+			var redditArgs args.RedditArguments
+			Expect(json.Unmarshal([]byte(`{"type":"searchposts","queries":["golang"]}`), &redditArgs)).To(Succeed())
+			req := redditArgs.ToRedditScraperRequest()
+			Expect(req.Sort).To(Equal(types.RedditSortNew))
+			Expect(req.Time).To(Equal(types.RedditTimeAll))
+			Expect(req.MaxItems).To(Equal(uint(10)))
+			Expect(req.MaxResults).To(Equal(uint(10)))
+			Expect(req.SkipComments).To(BeFalse())
+			Expect(req.MaxCommunitiesCount).To(Equal(uint(2)))
+
+			// MaxResults is for the worker, the actor does not know about it
+			data, err := json.Marshal(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).ToNot(ContainSubstring("MaxResults"))
+		})
+	})
 })
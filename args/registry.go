@@ -0,0 +1,191 @@
+package args
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var (
+	ErrRegistryNilFactory        = errors.New("arguments factory must not be nil")
+	ErrRegistryAlreadyRegistered = errors.New("arguments already registered")
+)
+
+// queryTypeKey is the JSON key used by all job arguments to carry the requested capability
+const queryTypeKey = "type"
+
+// ArgumentsFactory returns a new, empty JobArguments value to unmarshal the raw arguments into
+type ArgumentsFactory func() JobArguments
+
+// ArgumentsValidator performs job-type-specific validation on freshly unmarshalled arguments
+type ArgumentsValidator func(jobType types.JobType, args JobArguments) error
+
+// jobTypeValidator is implemented by the arguments that know how to validate themselves for a job type
+type jobTypeValidator interface {
+	ValidateForJobType(jobType types.JobType) error
+}
+
+type registration struct {
+	factory   ArgumentsFactory
+	validator ArgumentsValidator
+}
+
+// Registry maps (JobType, Capability) pairs to the JobArguments implementation that handles them.
+// It is safe for concurrent use.
+type Registry struct {
+	mu       sync.RWMutex
+	entries  map[types.JobType]map[types.Capability]registration
+	defaults map[types.JobType]types.Capability
+}
+
+// NewRegistry returns an empty Registry
+func NewRegistry() *Registry {
+	return &Registry{
+		entries:  make(map[types.JobType]map[types.Capability]registration),
+		defaults: make(map[types.JobType]types.Capability),
+	}
+}
+
+// Register associates a (JobType, Capability) pair with an arguments factory and an optional validator.
+// If validator is nil, the arguments' own ValidateForJobType method is used when available.
+func (r *Registry) Register(jobType types.JobType, capability types.Capability, factory ArgumentsFactory, validator ArgumentsValidator) error {
+	if factory == nil {
+		return fmt.Errorf("%w: %s/%s", ErrRegistryNilFactory, jobType, capability)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	caps, exists := r.entries[jobType]
+	if !exists {
+		caps = make(map[types.Capability]registration)
+		r.entries[jobType] = caps
+	}
+	if _, exists := caps[capability]; exists {
+		return fmt.Errorf("%w: %s/%s", ErrRegistryAlreadyRegistered, jobType, capability)
+	}
+
+	caps[capability] = registration{factory: factory, validator: validator}
+	return nil
+}
+
+// SetDefaultCapability sets the capability used when the arguments for jobType do not specify one
+func (r *Registry) SetDefaultCapability(jobType types.JobType, capability types.Capability) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.defaults[jobType] = capability
+}
+
+// DefaultCapability returns the default capability for jobType, if one is configured
+func (r *Registry) DefaultCapability(jobType types.JobType) (types.Capability, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	capability, exists := r.defaults[jobType]
+	return capability, exists
+}
+
+// JobTypes returns all the job types with at least one registered capability, sorted
+func (r *Registry) JobTypes() []types.JobType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.entries))
+}
+
+// Capabilities returns the capabilities registered for jobType, sorted
+func (r *Registry) Capabilities(jobType types.JobType) []types.Capability {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.Sorted(maps.Keys(r.entries[jobType]))
+}
+
+// New returns a new, empty JobArguments value for the given (JobType, Capability) pair
+func (r *Registry) New(jobType types.JobType, capability types.Capability) (JobArguments, error) {
+	reg, err := r.lookup(jobType, capability)
+	if err != nil {
+		return nil, err
+	}
+	return reg.factory(), nil
+}
+
+// Unmarshal resolves the capability requested in args (or the default for jobType), unmarshals args
+// into the registered JobArguments implementation and runs its validation hook.
+func (r *Registry) Unmarshal(jobType types.JobType, args map[string]any) (JobArguments, error) {
+	minimal := &QueryTypeArgument{}
+	if err := unmarshalToStruct(args, minimal); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s arguments: %w", jobType, err)
+	}
+
+	capability := types.Capability(minimal.QueryType)
+	if capability == types.CapEmpty {
+		if defaultCap, exists := r.DefaultCapability(jobType); exists {
+			capability = defaultCap
+			// Make the default visible to the arguments' own unmarshalling and validation
+			args = maps.Clone(args)
+			if args == nil {
+				args = make(map[string]any, 1)
+			}
+			args[queryTypeKey] = string(capability)
+		}
+	}
+
+	reg, err := r.lookup(jobType, capability)
+	if err != nil {
+		return nil, err
+	}
+
+	jobArgs := reg.factory()
+	if err := unmarshalToStruct(args, jobArgs); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s %s arguments: %w", jobType, capability, err)
+	}
+
+	validator := reg.validator
+	if validator == nil {
+		validator = validateForJobType
+	}
+	if err := validator(jobType, jobArgs); err != nil {
+		return nil, fmt.Errorf("%s job validation failed: %w", jobType, err)
+	}
+
+	return jobArgs, nil
+}
+
+func (r *Registry) lookup(jobType types.JobType, capability types.Capability) (registration, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	caps, exists := r.entries[jobType]
+	if !exists {
+		return registration{}, fmt.Errorf("unknown job type: %s", jobType)
+	}
+	reg, exists := caps[capability]
+	if !exists {
+		return registration{}, fmt.Errorf("capability '%s' is not registered for job type '%s'. registered capabilities: %v",
+			capability, jobType, slices.Sorted(maps.Keys(caps)))
+	}
+	return reg, nil
+}
+
+// validateForJobType is the default validation hook, used when a registration does not provide one
+func validateForJobType(jobType types.JobType, args JobArguments) error {
+	if v, ok := args.(jobTypeValidator); ok {
+		return v.ValidateForJobType(jobType)
+	}
+	return nil
+}
+
+// DefaultRegistry holds the built-in job arguments and any registered by downstream services
+var DefaultRegistry = newDefaultRegistry()
+
+// Register adds a (JobType, Capability) registration to the DefaultRegistry
+func Register(jobType types.JobType, capability types.Capability, factory ArgumentsFactory, validator ArgumentsValidator) error {
+	return DefaultRegistry.Register(jobType, capability, factory, validator)
+}
+
+// SetDefaultCapability sets the default capability for jobType in the DefaultRegistry
+func SetDefaultCapability(jobType types.JobType, capability types.Capability) {
+	DefaultRegistry.SetDefaultCapability(jobType, capability)
+}
@@ -0,0 +1,110 @@
+package args_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+const (
+	customJob types.JobType    = "custom"
+	customCap types.Capability = "custom-capability"
+)
+
+type customArguments struct {
+	QueryType string `json:"type"`
+	Value     int    `json:"value"`
+}
+
+func (c *customArguments) GetCapability() types.Capability {
+	return types.Capability(c.QueryType)
+}
+
+var _ = Describe("Registry", func() {
+	var registry *args.Registry
+
+	BeforeEach(func() {
+		registry = args.NewRegistry()
+	})
+
+	It("should unmarshal arguments for a registered job type", func() {
+		err := registry.Register(customJob, customCap, func() args.JobArguments { return &customArguments{} }, nil)
+		Expect(err).ToNot(HaveOccurred())
+
+		jobArgs, err := registry.Unmarshal(customJob, map[string]any{"type": "custom-capability", "value": 42})
+		Expect(err).ToNot(HaveOccurred())
+		customArgs, ok := jobArgs.(*customArguments)
+		Expect(ok).To(BeTrue())
+		Expect(customArgs.Value).To(Equal(42))
+		Expect(customArgs.GetCapability()).To(Equal(customCap))
+	})
+
+	It("should apply the default capability when none is given", func() {
+		err := registry.Register(customJob, customCap, func() args.JobArguments { return &customArguments{} }, nil)
+		Expect(err).ToNot(HaveOccurred())
+		registry.SetDefaultCapability(customJob, customCap)
+
+		argsMap := map[string]any{"value": 1}
+		jobArgs, err := registry.Unmarshal(customJob, argsMap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(jobArgs.GetCapability()).To(Equal(customCap))
+		Expect(argsMap).ToNot(HaveKey("type"))
+	})
+
+	It("should run the validation hook", func() {
+		errTooBig := errors.New("value too big")
+		err := registry.Register(customJob, customCap, func() args.JobArguments { return &customArguments{} },
+			func(jobType types.JobType, jobArgs args.JobArguments) error {
+				if jobArgs.(*customArguments).Value > 10 {
+					return errTooBig
+				}
+				return nil
+			})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = registry.Unmarshal(customJob, map[string]any{"type": "custom-capability", "value": 11})
+		Expect(errors.Is(err, errTooBig)).To(BeTrue())
+	})
+
+	It("should reject duplicate registrations", func() {
+		factory := func() args.JobArguments { return &customArguments{} }
+		Expect(registry.Register(customJob, customCap, factory, nil)).To(Succeed())
+		err := registry.Register(customJob, customCap, factory, nil)
+		Expect(errors.Is(err, args.ErrRegistryAlreadyRegistered)).To(BeTrue())
+	})
+
+	It("should reject a nil factory", func() {
+		err := registry.Register(customJob, customCap, nil, nil)
+		Expect(errors.Is(err, args.ErrRegistryNilFactory)).To(BeTrue())
+	})
+
+	It("should fail for unregistered capabilities", func() {
+		Expect(registry.Register(customJob, customCap, func() args.JobArguments { return &customArguments{} }, nil)).To(Succeed())
+		_, err := registry.Unmarshal(customJob, map[string]any{"type": "other"})
+		Expect(err).To(MatchError(ContainSubstring("not registered for job type")))
+	})
+
+	It("should fail for unknown job types", func() {
+		_, err := registry.Unmarshal(customJob, map[string]any{})
+		Expect(err).To(MatchError(ContainSubstring("unknown job type")))
+	})
+
+	Describe("DefaultRegistry", func() {
+		It("should contain all the built-in job types", func() {
+			Expect(args.DefaultRegistry.JobTypes()).To(ContainElements(
+				types.WebJob, types.TiktokJob, types.TwitterJob, types.TwitterCredentialJob,
+				types.TwitterApiJob, types.TwitterApifyJob, types.LinkedInJob, types.RedditJob, types.TelemetryJob,
+			))
+		})
+
+		It("should register the TikTok capabilities", func() {
+			Expect(args.DefaultRegistry.Capabilities(types.TiktokJob)).To(ConsistOf(
+				types.CapTranscription, types.CapSearchByQuery, types.CapSearchByTrending,
+			))
+		})
+	})
+})
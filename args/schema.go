@@ -0,0 +1,217 @@
+package args
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/masa-finance/tee-types/pkg/util"
+	"github.com/masa-finance/tee-types/types"
+)
+
+// JSONSchemaDraft is the JSON Schema dialect produced by the schema generator
+const JSONSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema is the subset of JSON Schema needed to describe job arguments
+type JSONSchema struct {
+	Schema      string                 `json:"$schema,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	Description string                 `json:"description,omitempty"`
+	Type        string                 `json:"type,omitempty"`
+	Format      string                 `json:"format,omitempty"`
+	Pattern     string                 `json:"pattern,omitempty"`
+	Properties  map[string]*JSONSchema `json:"properties,omitempty"`
+	Required    []string               `json:"required,omitempty"`
+	Items       *JSONSchema            `json:"items,omitempty"`
+	Enum        []any                  `json:"enum,omitempty"`
+	Const       any                    `json:"const,omitempty"`
+	Default     any                    `json:"default,omitempty"`
+	Minimum     *float64               `json:"minimum,omitempty"`
+	Maximum     *float64               `json:"maximum,omitempty"`
+	MinItems    *int                   `json:"minItems,omitempty"`
+	AnyOf       []*JSONSchema          `json:"anyOf,omitempty"`
+}
+
+// SchemaAnnotator is implemented by job arguments that add validation rules (enums, defaults, minimums, required fields)
+// on top of the schema derived from their Go type
+type SchemaAnnotator interface {
+	AnnotateSchema(capability types.Capability, schema *JSONSchema)
+}
+
+// Property returns the schema of the named property, or nil if the schema has no such property
+func (s *JSONSchema) Property(name string) *JSONSchema {
+	return s.Properties[name]
+}
+
+// SetRequired marks the given properties as required, keeping the list sorted and deduplicated
+func (s *JSONSchema) SetRequired(names ...string) *JSONSchema {
+	s.Required = append(s.Required, names...)
+	slices.Sort(s.Required)
+	s.Required = slices.Compact(s.Required)
+	return s
+}
+
+// SetMinimum sets the inclusive minimum for a numeric schema
+func (s *JSONSchema) SetMinimum(minimum float64) *JSONSchema {
+	s.Minimum = &minimum
+	return s
+}
+
+// SetMaximum sets the inclusive maximum for a numeric schema
+func (s *JSONSchema) SetMaximum(maximum float64) *JSONSchema {
+	s.Maximum = &maximum
+	return s
+}
+
+// SetMinItems sets the minimum number of items for an array schema
+func (s *JSONSchema) SetMinItems(minItems int) *JSONSchema {
+	s.MinItems = &minItems
+	return s
+}
+
+// SetDefault sets the default value of the schema
+func (s *JSONSchema) SetDefault(value any) *JSONSchema {
+	s.Default = value
+	return s
+}
+
+// SetEnum sets the allowed values of the schema
+func (s *JSONSchema) SetEnum(values ...any) *JSONSchema {
+	s.Enum = values
+	return s
+}
+
+// enumFromSet returns the items of a set as a sorted enum, so the generated schema is deterministic
+func enumFromSet[T cmp.Ordered](set *util.Set[T]) []any {
+	items := set.Items()
+	slices.Sort(items)
+	ret := make([]any, len(items))
+	for i, item := range items {
+		ret[i] = item
+	}
+	return ret
+}
+
+// SchemaFor derives a JSON Schema document from the Go type of args, then applies its SchemaAnnotator if it has one
+func SchemaFor(args any, capability types.Capability) *JSONSchema {
+	schema := schemaForType(reflect.TypeOf(args))
+	schema.Schema = JSONSchemaDraft
+
+	if capability != types.CapEmpty {
+		if queryType := schema.Property(queryTypeKey); queryType != nil {
+			queryType.Const = string(capability)
+		}
+	}
+
+	if annotator, ok := args.(SchemaAnnotator); ok {
+		annotator.AnnotateSchema(capability, schema)
+	}
+
+	return schema
+}
+
+// Schema returns the JSON Schema document for the arguments registered for the (JobType, Capability) pair
+func (r *Registry) Schema(jobType types.JobType, capability types.Capability) (*JSONSchema, error) {
+	jobArgs, err := r.New(jobType, capability)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := SchemaFor(jobArgs, capability)
+	schema.Title = fmt.Sprintf("%s %s arguments", jobType, capability)
+	return schema, nil
+}
+
+// Schemas returns the JSON Schema documents for all the registered (JobType, Capability) pairs
+func (r *Registry) Schemas() map[types.JobType]map[types.Capability]*JSONSchema {
+	ret := make(map[types.JobType]map[types.Capability]*JSONSchema)
+	for _, jobType := range r.JobTypes() {
+		ret[jobType] = make(map[types.Capability]*JSONSchema)
+		for _, capability := range r.Capabilities(jobType) {
+			schema, err := r.Schema(jobType, capability)
+			if err != nil {
+				// Can only happen if a registration was removed concurrently
+				continue
+			}
+			ret[jobType][capability] = schema
+		}
+	}
+	return ret
+}
+
+// GenerateSchema returns the JSON Schema document for a (JobType, Capability) pair in the DefaultRegistry
+func GenerateSchema(jobType types.JobType, capability types.Capability) (*JSONSchema, error) {
+	return DefaultRegistry.Schema(jobType, capability)
+}
+
+// GenerateSchemas returns the JSON Schema documents for all the (JobType, Capability) pairs in the DefaultRegistry
+func GenerateSchemas() map[types.JobType]map[types.Capability]*JSONSchema {
+	return DefaultRegistry.Schemas()
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func schemaForType(t reflect.Type) *JSONSchema {
+	if t == timeType {
+		return &JSONSchema{Type: "string", Format: "date-time"}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaForType(t.Elem())
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return (&JSONSchema{Type: "integer"}).SetMinimum(0)
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: schemaForType(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object"}
+	case reflect.Struct:
+		schema := &JSONSchema{Type: "object", Properties: make(map[string]*JSONSchema)}
+		addStructProperties(t, schema)
+		return schema
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// addStructProperties adds the JSON-visible fields of t (including those of embedded structs) to schema
+func addStructProperties(t reflect.Type, schema *JSONSchema) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, _, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				addStructProperties(ft, schema)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		schema.Properties[name] = schemaForType(field.Type)
+	}
+}
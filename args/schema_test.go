@@ -0,0 +1,93 @@
+package args_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("JSON Schema generation", func() {
+	It("should generate a schema for every registered job type and capability", func() {
+		schemas := args.GenerateSchemas()
+		for _, jobType := range args.DefaultRegistry.JobTypes() {
+			Expect(schemas).To(HaveKey(jobType))
+			for _, capability := range args.DefaultRegistry.Capabilities(jobType) {
+				schema := schemas[jobType][capability]
+				Expect(schema).ToNot(BeNil())
+				Expect(schema.Schema).To(Equal(args.JSONSchemaDraft))
+				Expect(schema.Type).To(Equal("object"))
+				_, err := json.Marshal(schema)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		}
+	})
+
+	It("should fail for unknown job types", func() {
+		_, err := args.GenerateSchema("unknown", types.CapScraper)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should include the Reddit enums, defaults and required fields", func() {
+		schema, err := args.GenerateSchema(types.RedditJob, types.CapSearchPosts)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schema.Property("type").Const).To(Equal("searchposts"))
+		Expect(schema.Property("sort").Enum).To(HaveLen(types.AllRedditSortTypes.Length()))
+		Expect(schema.Property("sort").Default).To(Equal(types.RedditSortNew))
+		Expect(schema.Property("time").Enum).To(HaveLen(types.AllRedditTimes.Length()))
+		Expect(schema.Property("time").Default).To(Equal(types.RedditTimeAll))
+		Expect(schema.Property("max_items").Default).To(Equal(10))
+		Expect(*schema.Property("max_items").Minimum).To(Equal(0.0))
+		Expect(schema.Property("after").Format).To(Equal("date-time"))
+		Expect(schema.Required).To(ConsistOf("queries"))
+
+		schema, err = args.GenerateSchema(types.RedditJob, types.CapScrapeUrls)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schema.Required).To(ConsistOf("urls"))
+	})
+
+	It("should include the TikTok trending enums and defaults", func() {
+		schema, err := args.GenerateSchema(types.TiktokJob, types.CapSearchByTrending)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schema.Property("country_code").Enum).To(ContainElement("US"))
+		Expect(schema.Property("country_code").Default).To(Equal("US"))
+		Expect(schema.Property("sort_by").Enum).To(ConsistOf("comment", "like", "repost", "vv"))
+		Expect(schema.Property("period").Enum).To(ConsistOf("30", "7"))
+		Expect(schema.Property("period").Default).To(Equal("7"))
+	})
+
+	It("should include the Web minimums and defaults", func() {
+		schema, err := args.GenerateSchema(types.WebJob, types.CapScraper)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(schema.Required).To(BeEmpty())
+		Expect(schema.AnyOf).To(HaveLen(2))
+		Expect(schema.AnyOf[0].Required).To(ConsistOf("url"))
+		Expect(schema.AnyOf[1].Required).To(ConsistOf("urls"))
+		Expect(schema.Property("urls").Items.Format).To(Equal("uri"))
+		Expect(schema.Property("scope").Enum).To(ConsistOf(types.WebScopePathPrefix, types.WebScopeSameDomain, types.WebScopeSameHost))
+		Expect(schema.Property("scope").Default).To(Equal(types.WebScopeSameHost))
+		Expect(schema.Property("robots").Enum).To(ConsistOf(types.WebRobotsIgnore, types.WebRobotsRespect))
+		Expect(schema.Property("robots").Default).To(Equal(types.WebRobotsIgnore))
+		Expect(*schema.Property("max_depth").Minimum).To(Equal(0.0))
+		Expect(*schema.Property("max_pages").Minimum).To(Equal(1.0))
+		Expect(schema.Property("max_pages").Default).To(Equal(args.WebDefaultMaxPages))
+	})
+
+	It("should include the Web sitemap limits and defaults", func() {
+		schema, err := args.GenerateSchema(types.WebJob, types.CapSitemap)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*schema.Property("max_depth").Maximum).To(Equal(0.0))
+		Expect(schema.Property("max_pages").Default).To(Equal(args.WebDefaultSitemapMaxPages))
+		Expect(schema.Property("modified_since").Format).To(Equal("date-time"))
+	})
+
+	It("should generate a schema for arguments outside the registry", func() {
+		schema := args.SchemaFor(&args.LLMProcessorArguments{}, types.CapEmpty)
+		Expect(schema.Required).To(ConsistOf("dataset_id", "prompt"))
+		Expect(schema.Property("temperature").Type).To(Equal("number"))
+		Expect(schema.Property("temperature").Default).To(Equal(args.LLMDefaultTemperature))
+	})
+})
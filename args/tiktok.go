@@ -5,11 +5,27 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
+	"github.com/masa-finance/tee-types/pkg/util"
 	teetypes "github.com/masa-finance/tee-types/types"
 )
 
+var (
+	ErrTikTokVideoURLRequired   = errors.New("is required")
+	ErrTikTokVideoURLInvalid    = errors.New("invalid URL format")
+	ErrTikTokNotTikTokURL       = errors.New("URL must be a valid TikTok video URL")
+	ErrTikTokShortURLUnresolved = errors.New("short URLs may redirect to any TikTok page, resolve it to a video URL first")
+	ErrTikTokStartURLInvalid    = errors.New("start URL must be a TikTok video, profile, hashtag, music, search or short URL")
+	ErrTikTokLanguageInvalid    = errors.New("invalid format")
+	ErrTikTokSearchRequired     = errors.New("either 'search' or 'start_urls' is required for searchbyquery")
+	ErrTikTokInvalidCountryCode = errors.New("unknown country code")
+	ErrTikTokInvalidSortBy      = errors.New("unknown sort order")
+	ErrTikTokInvalidPeriod      = errors.New("unknown period")
+	ErrTikTokMaxItemsNegative   = errors.New("must be non-negative")
+)
+
 // Period constants for TikTok trending search
 const (
 	periodWeek  string = "7"
@@ -23,6 +39,22 @@ const (
 	sortRepost   string = "repost"
 )
 
+const tiktokDefaultCountryCode = "US"
+
+var (
+	tiktokAllowedSorts   = util.NewSet(sortTrending, sortLike, sortComment, sortRepost)
+	tiktokAllowedPeriods = util.NewSet(periodWeek, periodMonth)
+
+	tiktokAllowedCountries = util.NewSet(
+		"AU", "BR", "CA", "EG", "FR", "DE", "ID", "IL", "IT", "JP",
+		"MY", "PH", "RU", "SA", "SG", "KR", "ES", "TW", "TH", "TR",
+		"AE", "GB", "US", "VN",
+	)
+)
+
+// tiktokLanguagePattern mirrors the checks in validateLanguageCode
+const tiktokLanguagePattern = "^[^-]{2,3}-[^-]{2}$"
+
 // TikTokTranscriptionArguments defines args for TikTok transcriptions
 type TikTokTranscriptionArguments struct {
 	VideoURL string `json:"video_url"`
@@ -48,29 +80,33 @@ func (t *TikTokTranscriptionArguments) UnmarshalJSON(data []byte) error {
 
 // Validate validates the TikTok arguments
 func (t *TikTokTranscriptionArguments) Validate() error {
-	if t.VideoURL == "" {
-		return errors.New("video_url is required")
-	}
-
-	// Validate URL format
-	parsedURL, err := url.Parse(t.VideoURL)
-	if err != nil {
-		return fmt.Errorf("invalid video_url format: %w", err)
-	}
+	var errs []error
 
-	// Basic TikTok URL validation
-	if !t.IsTikTokURL(parsedURL) {
-		return errors.New("URL must be a valid TikTok video URL")
+	if t.VideoURL == "" {
+		errs = append(errs, NewValidationError("video_url", CodeRequired, nil, ErrTikTokVideoURLRequired))
+	} else if parsedURL, err := teetypes.ParseTikTokURL(t.VideoURL); err != nil {
+		if errors.Is(err, teetypes.ErrNotTikTokURL) {
+			errs = append(errs, NewValidationError("video_url", CodeInvalidValue, t.VideoURL, fmt.Errorf("%w: %w", ErrTikTokNotTikTokURL, err)))
+		} else {
+			errs = append(errs, NewValidationError("video_url", CodeInvalidFormat, t.VideoURL, fmt.Errorf("%w: %w", ErrTikTokVideoURLInvalid, err)))
+		}
+	} else if parsedURL.Kind == teetypes.TikTokShortURL {
+		// Short URLs cannot be resolved offline, and they may point to a profile or a hashtag as well as a video
+		errs = append(errs, NewValidationError("video_url", CodeInvalidValue, t.VideoURL, fmt.Errorf("%w: %w", ErrTikTokNotTikTokURL, ErrTikTokShortURLUnresolved)))
+	} else if !parsedURL.IsVideo() {
+		errs = append(errs, NewValidationError("video_url", CodeInvalidValue, t.VideoURL, fmt.Errorf("%w, got a %s URL", ErrTikTokNotTikTokURL, parsedURL.Kind)))
+	} else if err := checkURLPolicy("video_url", t.VideoURL); err != nil {
+		errs = append(errs, err)
 	}
 
 	// Validate language format if provided
 	if t.Language != "" {
 		if err := t.validateLanguageCode(); err != nil {
-			return err
+			errs = append(errs, NewValidationError("language", CodeInvalidFormat, t.Language, err))
 		}
 	}
 
-	return nil
+	return errors.Join(errs...)
 }
 
 // GetCapability returns the capability for TikTok operations (always transcription)
@@ -78,7 +114,15 @@ func (t *TikTokTranscriptionArguments) GetCapability() teetypes.Capability {
 	return teetypes.CapTranscription
 }
 
+// AnnotateSchema adds the TikTok transcription validation rules to the generated JSON Schema
+func (t *TikTokTranscriptionArguments) AnnotateSchema(_ teetypes.Capability, schema *JSONSchema) {
+	schema.SetRequired("video_url")
+	schema.Property("video_url").Format = "uri"
+	schema.Property("language").Pattern = tiktokLanguagePattern
+}
+
 // IsTikTokURL validates if the URL is a TikTok URL
+// It only checks the host, use ParsedVideoURL to check that the URL points to a video
 func (t *TikTokTranscriptionArguments) IsTikTokURL(parsedURL *url.URL) bool {
 	host := strings.ToLower(parsedURL.Host)
 	return host == "tiktok.com" || strings.HasSuffix(host, ".tiktok.com")
@@ -89,6 +133,11 @@ func (t *TikTokTranscriptionArguments) HasLanguagePreference() bool {
 	return t.Language != ""
 }
 
+// ParsedVideoURL parses and classifies the source video URL
+func (t *TikTokTranscriptionArguments) ParsedVideoURL() (*teetypes.TikTokURL, error) {
+	return teetypes.ParseTikTokURL(t.VideoURL)
+}
+
 // GetVideoURL returns the source video URL
 func (t *TikTokTranscriptionArguments) GetVideoURL() string {
 	return t.VideoURL
@@ -104,12 +153,8 @@ func (t *TikTokTranscriptionArguments) GetLanguageCode() string {
 
 // ValidateForJobType validates TikTok arguments for a specific job type
 func (t *TikTokTranscriptionArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := t.Validate(); err != nil {
-		return err
-	}
-
-	// Validate capability against job-specific capabilities
-	return jobType.ValidateCapability(t.GetCapability())
+	// Validate capability against job-specific capabilities, reporting it along with any field errors
+	return errors.Join(t.Validate(), validateCapability(jobType, t.GetCapability()))
 }
 
 // validateLanguageCode validates the language code format
@@ -117,12 +162,12 @@ func (t *TikTokTranscriptionArguments) validateLanguageCode() error {
 	// Basic validation for language codes like "en-us", "eng-us", "es-es", etc.
 	parts := strings.Split(t.Language, "-")
 	if len(parts) != 2 {
-		return fmt.Errorf("invalid language format '%s', expected format: 'lang-region' (e.g., 'en-us' or 'eng-us')", t.Language)
+		return fmt.Errorf("%w '%s', expected format: 'lang-region' (e.g., 'en-us' or 'eng-us')", ErrTikTokLanguageInvalid, t.Language)
 	}
 
 	// Language code can be 2 or 3 letters, region must be 2 letters
 	if (len(parts[0]) != 2 && len(parts[0]) != 3) || len(parts[1]) != 2 {
-		return fmt.Errorf("invalid language format '%s', expected 2-3 letter language code and 2-letter region code", t.Language)
+		return fmt.Errorf("%w '%s', expected 2-3 letter language code and 2-letter region code", ErrTikTokLanguageInvalid, t.Language)
 	}
 
 	return nil
@@ -150,22 +195,52 @@ func (t *TikTokSearchByQueryArguments) UnmarshalJSON(data []byte) error {
 
 func (t *TikTokSearchByQueryArguments) Validate() error {
 	if len(t.Search) == 0 && len(t.StartUrls) == 0 {
-		return errors.New("either 'search' or 'start_urls' is required for searchbyquery")
+		return NewValidationError("search", CodeRequired, nil, ErrTikTokSearchRequired)
 	}
-	return nil
+
+	var errs []error
+	for i, startURL := range t.StartUrls {
+		field := fmt.Sprintf("start_urls[%d]", i)
+		parsedURL, err := teetypes.ParseTikTokURL(startURL)
+		if err != nil {
+			errs = append(errs, NewValidationError(field, CodeInvalidFormat, startURL, fmt.Errorf("%w: %w", ErrTikTokStartURLInvalid, err)))
+		} else if parsedURL.Kind == teetypes.TikTokOtherURL {
+			errs = append(errs, NewValidationError(field, CodeInvalidValue, startURL, ErrTikTokStartURLInvalid))
+		} else if err := checkURLPolicy(field, startURL); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
 }
 
 func (t *TikTokSearchByQueryArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := jobType.ValidateCapability(teetypes.CapSearchByQuery); err != nil {
-		return err
-	}
-	return t.Validate()
+	return errors.Join(t.Validate(), validateCapability(jobType, teetypes.CapSearchByQuery))
 }
 
 func (t *TikTokSearchByQueryArguments) GetCapability() teetypes.Capability {
 	return teetypes.CapSearchByQuery
 }
 
+// ToTikTokSearchRequest builds the epctex/tiktok-search-scraper input for the arguments
+func (t TikTokSearchByQueryArguments) ToTikTokSearchRequest() teetypes.TikTokSearchRequest {
+	return teetypes.TikTokSearchRequest{
+		Search:    t.Search,
+		StartUrls: t.StartUrls,
+		MaxItems:  t.MaxItems,
+		EndPage:   t.EndPage,
+	}
+}
+
+// AnnotateSchema adds the TikTok searchbyquery validation rules to the generated JSON Schema
+func (t *TikTokSearchByQueryArguments) AnnotateSchema(_ teetypes.Capability, schema *JSONSchema) {
+	nonEmpty := func(name string) *JSONSchema {
+		s := &JSONSchema{Properties: map[string]*JSONSchema{name: (&JSONSchema{}).SetMinItems(1)}}
+		return s.SetRequired(name)
+	}
+	schema.AnyOf = []*JSONSchema{nonEmpty("search"), nonEmpty("start_urls")}
+	schema.Property("start_urls").Items.Format = "uri"
+}
+
 // TikTokSearchByTrendingArguments defines args for lexis-solutions/tiktok-trending-videos-scraper
 type TikTokSearchByTrendingArguments struct {
 	QueryType   string `json:"type"`
@@ -183,8 +258,13 @@ func (t *TikTokSearchByTrendingArguments) UnmarshalJSON(data []byte) error {
 		return fmt.Errorf("failed to unmarshal TikTok searchbytrending arguments: %w", err)
 	}
 	t.QueryType = strings.ToLower(t.QueryType)
+	t.setDefaultValues()
+	return t.Validate()
+}
+
+func (t *TikTokSearchByTrendingArguments) setDefaultValues() {
 	if t.CountryCode == "" {
-		t.CountryCode = "US"
+		t.CountryCode = tiktokDefaultCountryCode
 	}
 	if t.SortBy == "" {
 		t.SortBy = sortTrending
@@ -192,52 +272,52 @@ func (t *TikTokSearchByTrendingArguments) UnmarshalJSON(data []byte) error {
 	if t.Period == "" {
 		t.Period = periodWeek
 	}
-	return t.Validate()
 }
 
 func (t *TikTokSearchByTrendingArguments) Validate() error {
-	allowedSorts := map[string]struct{}{
-		sortTrending: {}, sortLike: {}, sortComment: {}, sortRepost: {},
-	}
+	var errs []error
 
-	allowedPeriods := map[string]struct{}{
-		periodWeek:  {},
-		periodMonth: {},
+	if !tiktokAllowedCountries.Contains(strings.ToUpper(t.CountryCode)) {
+		errs = append(errs, NewValidationError("country_code", CodeInvalidValue, t.CountryCode, fmt.Errorf("%w '%s'", ErrTikTokInvalidCountryCode, t.CountryCode)))
 	}
-
-	allowedCountries := map[string]struct{}{
-		"AU": {}, "BR": {}, "CA": {}, "EG": {}, "FR": {}, "DE": {}, "ID": {}, "IL": {}, "IT": {}, "JP": {},
-		"MY": {}, "PH": {}, "RU": {}, "SA": {}, "SG": {}, "KR": {}, "ES": {}, "TW": {}, "TH": {}, "TR": {},
-		"AE": {}, "GB": {}, "US": {}, "VN": {},
+	if !tiktokAllowedSorts.Contains(strings.ToLower(t.SortBy)) {
+		errs = append(errs, NewValidationError("sort_by", CodeInvalidValue, t.SortBy, fmt.Errorf("%w '%s'", ErrTikTokInvalidSortBy, t.SortBy)))
 	}
-
-	if _, ok := allowedCountries[strings.ToUpper(t.CountryCode)]; !ok {
-		return fmt.Errorf("invalid country_code '%s'", t.CountryCode)
-	}
-	if _, ok := allowedSorts[strings.ToLower(t.SortBy)]; !ok {
-		return fmt.Errorf("invalid sort_by '%s'", t.SortBy)
-	}
-	if _, ok := allowedPeriods[t.Period]; !ok {
-		// Extract keys for error message
-		var validKeys []string
-		for key := range allowedPeriods {
-			validKeys = append(validKeys, key)
-		}
-		return fmt.Errorf("invalid period '%s' (allowed: %s)", t.Period, strings.Join(validKeys, ", "))
+	if !tiktokAllowedPeriods.Contains(t.Period) {
+		validKeys := tiktokAllowedPeriods.Items()
+		slices.Sort(validKeys)
+		errs = append(errs, NewValidationError("period", CodeInvalidValue, t.Period, fmt.Errorf("%w '%s' (allowed: %s)", ErrTikTokInvalidPeriod, t.Period, strings.Join(validKeys, ", "))))
 	}
 	if t.MaxItems < 0 {
-		return fmt.Errorf("max_items must be non-negative, got: %d", t.MaxItems)
+		errs = append(errs, NewValidationError("max_items", CodeOutOfRange, t.MaxItems, fmt.Errorf("%w, got: %d", ErrTikTokMaxItemsNegative, t.MaxItems)))
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (t *TikTokSearchByTrendingArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := jobType.ValidateCapability(teetypes.CapSearchByTrending); err != nil {
-		return err
-	}
-	return t.Validate()
+	return errors.Join(t.Validate(), validateCapability(jobType, teetypes.CapSearchByTrending))
 }
 
 func (t *TikTokSearchByTrendingArguments) GetCapability() teetypes.Capability {
 	return teetypes.CapSearchByTrending
 }
+
+// ToTikTokTrendingRequest builds the lexis-solutions/tiktok-trending-videos-scraper input for the arguments,
+// applying the defaults for any value that was not provided
+func (t TikTokSearchByTrendingArguments) ToTikTokTrendingRequest() teetypes.TikTokTrendingRequest {
+	t.setDefaultValues()
+	return teetypes.TikTokTrendingRequest{
+		CountryCode: strings.ToUpper(t.CountryCode),
+		Sort:        strings.ToLower(t.SortBy),
+		MaxItems:    t.MaxItems,
+		Period:      t.Period,
+	}
+}
+
+// AnnotateSchema adds the TikTok searchbytrending validation rules and defaults to the generated JSON Schema
+func (t *TikTokSearchByTrendingArguments) AnnotateSchema(_ teetypes.Capability, schema *JSONSchema) {
+	schema.Property("country_code").SetEnum(enumFromSet(tiktokAllowedCountries)...).SetDefault(tiktokDefaultCountryCode)
+	schema.Property("sort_by").SetEnum(enumFromSet(tiktokAllowedSorts)...).SetDefault(sortTrending)
+	schema.Property("period").SetEnum(enumFromSet(tiktokAllowedPeriods)...).SetDefault(periodWeek)
+	schema.Property("max_items").SetMinimum(0)
+}
@@ -0,0 +1,104 @@
+package args_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+)
+
+var _ = Describe("TikTok arguments", func() {
+	Describe("TikTokTranscriptionArguments validation", func() {
+		It("should accept video URLs", func() {
+			transcriptionArgs := &args.TikTokTranscriptionArguments{VideoURL: "https://www.tiktok.com/@user/video/123"}
+			Expect(transcriptionArgs.Validate()).To(Succeed())
+		})
+
+		It("should reject unresolved short URLs", func() {
+			for _, videoURL := range []string{"https://vm.tiktok.com/ZMabc123/", "https://www.tiktok.com/t/ZTabc123/"} {
+				transcriptionArgs := &args.TikTokTranscriptionArguments{VideoURL: videoURL}
+				err := transcriptionArgs.Validate()
+				Expect(errors.Is(err, args.ErrTikTokShortURLUnresolved)).To(BeTrue(), videoURL)
+				Expect(errors.Is(err, args.ErrTikTokNotTikTokURL)).To(BeTrue(), videoURL)
+			}
+		})
+
+		It("should reject non-video TikTok URLs", func() {
+			for _, videoURL := range []string{"https://www.tiktok.com/@user", "https://www.tiktok.com/tag/golang", "https://www.tiktok.com/search?q=x"} {
+				transcriptionArgs := &args.TikTokTranscriptionArguments{VideoURL: videoURL}
+				err := transcriptionArgs.Validate()
+				Expect(errors.Is(err, args.ErrTikTokNotTikTokURL)).To(BeTrue(), videoURL)
+			}
+		})
+
+		It("should reject URLs outside tiktok.com", func() {
+			transcriptionArgs := &args.TikTokTranscriptionArguments{VideoURL: "https://example.com/@user/video/123"}
+			Expect(errors.Is(transcriptionArgs.Validate(), args.ErrTikTokNotTikTokURL)).To(BeTrue())
+		})
+
+		It("should expose the parsed URL", func() {
+			transcriptionArgs := &args.TikTokTranscriptionArguments{VideoURL: "https://www.tiktok.com/@user/video/123?lang=en"}
+			parsed, err := transcriptionArgs.ParsedVideoURL()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.VideoID).To(Equal("123"))
+			Expect(parsed.CanonicalURL()).To(Equal("https://www.tiktok.com/@user/video/123"))
+		})
+	})
+
+	Describe("TikTokSearchByQueryArguments validation", func() {
+		It("should validate the start URLs", func() {
+			searchArgs := &args.TikTokSearchByQueryArguments{
+				StartUrls: []string{"https://www.tiktok.com/@user", "https://www.tiktok.com/about", "https://example.com"},
+			}
+			validationErrs := args.ValidationErrors(searchArgs.Validate())
+			Expect(validationErrs).To(HaveLen(2))
+			Expect(validationErrs[0].Field).To(Equal("start_urls[1]"))
+			Expect(validationErrs[1].Field).To(Equal("start_urls[2]"))
+			Expect(errors.Is(validationErrs[1], args.ErrTikTokStartURLInvalid)).To(BeTrue())
+		})
+	})
+
+	Describe("ToTikTokSearchRequest", func() {
+		It("should map fields correctly", func() {
+			searchArgs := args.TikTokSearchByQueryArguments{
+				Search:    []string{"golang"},
+				StartUrls: []string{"https://www.tiktok.com/@user/video/123"},
+				MaxItems:  20,
+				EndPage:   2,
+			}
+			req := searchArgs.ToTikTokSearchRequest()
+			Expect(req.Search).To(Equal([]string{"golang"}))
+			Expect(req.StartUrls).To(Equal([]string{"https://www.tiktok.com/@user/video/123"}))
+			Expect(req.MaxItems).To(Equal(uint(20)))
+			Expect(req.EndPage).To(Equal(uint(2)))
+		})
+	})
+
+	Describe("ToTikTokTrendingRequest", func() {
+		It("should apply the defaults", func() {
+			req := args.TikTokSearchByTrendingArguments{}.ToTikTokTrendingRequest()
+			Expect(req.CountryCode).To(Equal("US"))
+			Expect(req.Sort).To(Equal("vv"))
+			Expect(req.Period).To(Equal("7"))
+			Expect(req.MaxItems).To(BeZero())
+		})
+
+		It("should map and normalize the provided values", func() {
+			var trendingArgs args.TikTokSearchByTrendingArguments
+			jsonData := []byte(`{"type":"searchbytrending","country_code":"gb","sort_by":"LIKE","period":"30","max_items":50}`)
+			Expect(json.Unmarshal(jsonData, &trendingArgs)).To(Succeed())
+			req := trendingArgs.ToTikTokTrendingRequest()
+			Expect(req.CountryCode).To(Equal("GB"))
+			Expect(req.Sort).To(Equal("like"))
+			Expect(req.Period).To(Equal("30"))
+			Expect(req.MaxItems).To(Equal(50))
+
+			data, err := json.Marshal(req)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(MatchJSON(`{"countryCode":"GB","sort":"like","period":"30","maxItems":50}`))
+		})
+	})
+})
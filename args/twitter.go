@@ -2,12 +2,39 @@ package args
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
 	"strings"
+	"time"
 
 	teetypes "github.com/masa-finance/tee-types/types"
 )
 
+var (
+	ErrTwitterCountNegative         = errors.New("must be non-negative")
+	ErrTwitterMaxResultsNegative    = errors.New("must be non-negative")
+	ErrTwitterTimeInvalid           = errors.New("invalid time, expected an RFC3339 timestamp or a YYYY-MM-DD date")
+	ErrTwitterStartTimeInTheFuture  = errors.New("is in the future")
+	ErrTwitterEndTimeBeforeStart    = errors.New("must be after start_time")
+	ErrTwitterTimeOutsideQueryRange = errors.New("searchbyquery only covers the last 7 days, use searchbyfullarchive for older tweets")
+	ErrTwitterSpaceIDRequired       = errors.New("must contain the Space ID for getspace")
+	ErrTwitterSpaceIDInvalid        = errors.New("invalid Space ID, expected 13 alphanumeric characters, e.g. 1DXxyRYNejbKM")
+	ErrTwitterLocationUnknown       = errors.New("unknown trends location, expected a WOEID or place name from the trend locations catalog")
+	ErrTwitterLocationNotAllowed    = errors.New("only supported by gettrends")
+)
+
+// twitterSpaceIDPattern matches Space IDs, as found in https://x.com/i/spaces/<id> URLs
+const twitterSpaceIDPattern = `^[A-Za-z0-9]{13}$`
+
+var twitterSpaceIDRe = regexp.MustCompile(twitterSpaceIDPattern)
+
+// TwitterSearchByQueryWindow is how far back in time searchbyquery can search; searchbyfullarchive has no limit
+const TwitterSearchByQueryWindow = 7 * 24 * time.Hour
+
+// twitterDateFormat is the date-only format accepted for StartTime and EndTime
+const twitterDateFormat = "2006-01-02"
+
 // TwitterSearchArguments defines args for Twitter searches
 type TwitterSearchArguments struct {
 	QueryType  string `json:"type"`  // Optional, type of search
@@ -17,6 +44,7 @@ type TwitterSearchArguments struct {
 	EndTime    string `json:"end_time"`    // Optional ISO timestamp
 	MaxResults int    `json:"max_results"` // Optional, max number of results
 	NextCursor string `json:"next_cursor"`
+	Location   string `json:"location"` // Optional, WOEID or place name of the trends to fetch, only for gettrends
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling with validation
@@ -39,29 +67,118 @@ func (t *TwitterSearchArguments) UnmarshalJSON(data []byte) error {
 	return t.Validate()
 }
 
+// parseTwitterTime parses an RFC3339 timestamp or a YYYY-MM-DD date into UTC, and reports whether it is a date
+func parseTwitterTime(s string) (ts time.Time, dateOnly bool, err error) {
+	if s == "" {
+		return time.Time{}, false, nil
+	}
+	if ts, err := time.Parse(time.RFC3339, s); err == nil {
+		return ts.UTC(), false, nil
+	}
+	if ts, err := time.Parse(twitterDateFormat, s); err == nil {
+		return ts, true, nil
+	}
+	return time.Time{}, false, fmt.Errorf("%w, got: %s", ErrTwitterTimeInvalid, s)
+}
+
+// twitterTimeBefore returns true if ts is before limit, comparing dates at day granularity so that e.g. a start date
+// 7 days ago is still within a 7-day window
+func twitterTimeBefore(ts time.Time, dateOnly bool, limit time.Time) bool {
+	if dateOnly {
+		y, m, d := limit.UTC().Date()
+		return ts.Before(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	return ts.Before(limit)
+}
+
+// twitterTimeAfter returns true if ts is after limit, comparing dates at day granularity
+func twitterTimeAfter(ts time.Time, dateOnly bool, limit time.Time) bool {
+	if dateOnly {
+		y, m, d := limit.UTC().Date()
+		return ts.After(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	return ts.After(limit)
+}
+
 // Validate validates the Twitter arguments (general validation)
 func (t *TwitterSearchArguments) Validate() error {
 	// note, query is not required for all capabilities
+	var errs []error
 
 	if t.Count < 0 {
-		return fmt.Errorf("count must be non-negative, got: %d", t.Count)
+		errs = append(errs, NewValidationError("count", CodeOutOfRange, t.Count, fmt.Errorf("%w, got: %d", ErrTwitterCountNegative, t.Count)))
 	}
 
 	if t.MaxResults < 0 {
-		return fmt.Errorf("max_results must be non-negative, got: %d", t.MaxResults)
+		errs = append(errs, NewValidationError("max_results", CodeOutOfRange, t.MaxResults, fmt.Errorf("%w, got: %d", ErrTwitterMaxResultsNegative, t.MaxResults)))
+	}
+
+	start, _, startErr := parseTwitterTime(t.StartTime)
+	if startErr != nil {
+		errs = append(errs, NewValidationError("start_time", CodeInvalidFormat, t.StartTime, startErr))
+	}
+	end, _, endErr := parseTwitterTime(t.EndTime)
+	if endErr != nil {
+		errs = append(errs, NewValidationError("end_time", CodeInvalidFormat, t.EndTime, endErr))
+	}
+	if startErr == nil && endErr == nil && !start.IsZero() && !end.IsZero() && !end.After(start) {
+		errs = append(errs, NewValidationError("end_time", CodeOutOfRange, t.EndTime, ErrTwitterEndTimeBeforeStart))
 	}
 
-	return nil
+	if t.IsSingleSpaceOperation() {
+		if t.Query == "" {
+			errs = append(errs, NewValidationError("query", CodeRequired, nil, ErrTwitterSpaceIDRequired))
+		} else if !twitterSpaceIDRe.MatchString(t.Query) {
+			errs = append(errs, NewValidationError("query", CodeInvalidFormat, t.Query, fmt.Errorf("%w, got: %s", ErrTwitterSpaceIDInvalid, t.Query)))
+		}
+	}
+
+	if t.Location != "" {
+		if !t.IsTrendsOperation() {
+			errs = append(errs, NewValidationError("location", CodeNotAllowed, t.Location, ErrTwitterLocationNotAllowed))
+		} else if _, ok := teetypes.LookupTrendLocation(t.Location); !ok {
+			errs = append(errs, NewValidationError("location", CodeInvalidValue, t.Location, fmt.Errorf("%w, got: %s", ErrTwitterLocationUnknown, t.Location)))
+		}
+	}
+
+	return errors.Join(errs...)
 }
 
-// ValidateForJobType validates Twitter arguments for a specific job type
+// validateTimeWindow checks the time range against the current time and the window of the requested capability.
+// It depends on the wall clock, so it is only run by ValidateForJobType and not when decoding stored arguments.
+func (t *TwitterSearchArguments) validateTimeWindow(now time.Time) []error {
+	var errs []error
+	start, startDateOnly, _ := parseTwitterTime(t.StartTime)
+	end, endDateOnly, _ := parseTwitterTime(t.EndTime)
+
+	if !start.IsZero() && twitterTimeAfter(start, startDateOnly, now) {
+		errs = append(errs, NewValidationError("start_time", CodeOutOfRange, t.StartTime, ErrTwitterStartTimeInTheFuture))
+	}
+
+	if t.GetCapability() == teetypes.CapSearchByQuery {
+		oldest := now.Add(-TwitterSearchByQueryWindow)
+		if !start.IsZero() && twitterTimeBefore(start, startDateOnly, oldest) {
+			errs = append(errs, NewValidationError("start_time", CodeOutOfRange, t.StartTime, ErrTwitterTimeOutsideQueryRange))
+		}
+		if !end.IsZero() && twitterTimeBefore(end, endDateOnly, oldest) {
+			errs = append(errs, NewValidationError("end_time", CodeOutOfRange, t.EndTime, ErrTwitterTimeOutsideQueryRange))
+		}
+	}
+
+	return errs
+}
+
+// ValidateForJobType validates Twitter arguments for a specific job type, including the time window checks that
+// depend on the current time
 func (t *TwitterSearchArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := t.Validate(); err != nil {
-		return err
+	// The time window can only be checked once the times are known to parse
+	err := t.Validate()
+	if err == nil {
+		err = errors.Join(t.validateTimeWindow(time.Now())...)
 	}
 
-	// Validate QueryType against job-specific capabilities
-	return jobType.ValidateCapability(teetypes.Capability(t.QueryType))
+	// Validate QueryType against job-specific capabilities, reporting it along with any field errors
+	return errors.Join(err, validateCapability(jobType, teetypes.Capability(t.QueryType)))
 }
 
 // GetCapability returns the QueryType as a typed Capability
@@ -69,6 +186,50 @@ func (t *TwitterSearchArguments) GetCapability() teetypes.Capability {
 	return teetypes.Capability(t.QueryType)
 }
 
+// GetStartTime parses StartTime into UTC, returning the zero time if it was not provided
+func (t *TwitterSearchArguments) GetStartTime() (time.Time, error) {
+	ts, _, err := parseTwitterTime(t.StartTime)
+	return ts, err
+}
+
+// GetEndTime parses EndTime into UTC, returning the zero time if it was not provided
+func (t *TwitterSearchArguments) GetEndTime() (time.Time, error) {
+	ts, _, err := parseTwitterTime(t.EndTime)
+	return ts, err
+}
+
+// HasTimeRange returns true if either StartTime or EndTime was provided
+func (t *TwitterSearchArguments) HasTimeRange() bool {
+	return t.StartTime != "" || t.EndTime != ""
+}
+
+// GetTrendLocation returns the location of the trends to fetch, defaulting to Worldwide if Location is not set
+func (t *TwitterSearchArguments) GetTrendLocation() teetypes.TrendLocation {
+	if location, ok := teetypes.LookupTrendLocation(t.Location); ok {
+		return location
+	}
+	location, _ := teetypes.TrendLocationByWOEID(teetypes.WorldwideWOEID)
+	return location
+}
+
+// ParseQuery parses Query into a structured TwitterQuery, see ParseTwitterQuery. It is meant for inspecting queries:
+// Validate does not require Query to be parsable, since Twitter tolerates queries that the parser rejects.
+func (t *TwitterSearchArguments) ParseQuery() (*TwitterQuery, error) {
+	return ParseTwitterQuery(t.Query)
+}
+
+// SetQuery renders a structured TwitterQuery into Query
+func (t *TwitterSearchArguments) SetQuery(q TwitterQuery) {
+	t.Query = q.String()
+}
+
+// IsQuerySearchOperation returns true if Query uses the search operator syntax
+func (t *TwitterSearchArguments) IsQuerySearchOperation() bool {
+	capability := t.GetCapability()
+	return capability == teetypes.CapSearchByQuery ||
+		capability == teetypes.CapSearchByFullArchive
+}
+
 func (t *TwitterSearchArguments) IsSingleTweetOperation() bool {
 	capability := t.GetCapability()
 	return capability == teetypes.CapGetById
@@ -107,3 +268,19 @@ func (t *TwitterSearchArguments) IsTrendsOperation() bool {
 	capability := t.GetCapability()
 	return capability == teetypes.CapGetTrends
 }
+
+// AnnotateSchema adds the Twitter validation rules to the generated JSON Schema
+func (t *TwitterSearchArguments) AnnotateSchema(capability teetypes.Capability, schema *JSONSchema) {
+	if capability == teetypes.CapGetSpace {
+		schema.SetRequired("query")
+		schema.Property("query").Pattern = twitterSpaceIDPattern
+		schema.Property("query").Description = "Space ID"
+	}
+	if capability == teetypes.CapGetTrends {
+		schema.Property("location").Description = "WOEID or place name, defaults to Worldwide"
+	}
+	schema.Property("count").SetMinimum(0)
+	schema.Property("max_results").SetMinimum(0)
+	schema.Property("start_time").Description = "RFC3339 timestamp or YYYY-MM-DD date"
+	schema.Property("end_time").Description = "RFC3339 timestamp or YYYY-MM-DD date"
+}
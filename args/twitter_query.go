@@ -0,0 +1,677 @@
+package args
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrTwitterQueryEmpty           = errors.New("query is empty")
+	ErrTwitterQuerySyntax          = errors.New("invalid query syntax")
+	ErrTwitterQueryInvalidTerm     = errors.New("invalid search term")
+	ErrTwitterQueryInvalidUsername = errors.New("invalid username")
+	ErrTwitterQueryInvalidHashtag  = errors.New("invalid hashtag")
+	ErrTwitterQueryInvalidLanguage = errors.New("invalid language code")
+	ErrTwitterQueryInvalidDate     = errors.New("invalid date")
+	ErrTwitterQueryInvalidRange    = errors.New("since must be before until")
+)
+
+var (
+	twitterUsernameRegex = regexp.MustCompile(`^@?[A-Za-z0-9_]{1,15}$`)
+	twitterHashtagRegex  = regexp.MustCompile(`^#?[\p{L}\p{N}_]+$`)
+	twitterLanguageRegex = regexp.MustCompile(`^[A-Za-z]{2,3}$`)
+)
+
+const (
+	twitterQueryDateFormat     = "2006-01-02"
+	twitterQueryDateTimeFormat = "2006-01-02_15:04:05_MST"
+)
+
+// TwitterQuery is a structured Twitter search query that renders to the operator syntax used by the
+// searchbyquery and searchbyfullarchive capabilities.
+// Filters are combined with AND, while multiple values of the same filter (e.g. several authors) are combined with OR.
+type TwitterQuery struct {
+	Keywords        []string  // All of these terms must match; phrases are quoted
+	AnyKeywords     []string  // At least one of these terms must match
+	ExcludeKeywords []string  // None of these terms may match
+	Authors         []string  // from:
+	ReplyTo         []string  // to:
+	Mentions        []string  // @user
+	Hashtags        []string  // #hashtag
+	Languages       []string  // lang:
+	Since           time.Time // since:, ignored if zero
+	Until           time.Time // until:, ignored if zero
+	ExcludeRetweets bool      // -is:retweet
+	ExcludeReplies  bool      // -is:reply
+	ExcludeQuotes   bool      // -is:quote
+	HasMedia        bool      // has:media
+	HasImages       bool      // has:images
+	HasVideos       bool      // has:videos
+	HasLinks        bool      // has:links
+	MinLikes        uint      // min_faves:, ignored if zero
+	MinRetweets     uint      // min_retweets:, ignored if zero
+	MinReplies      uint      // min_replies:, ignored if zero
+	Extra           []string  // Operators not modelled above, rendered verbatim
+}
+
+// String renders the query using the Twitter search operator syntax
+func (q TwitterQuery) String() string {
+	var parts []string
+
+	for _, kw := range q.Keywords {
+		parts = append(parts, quoteTwitterTerm(kw))
+	}
+	parts = appendTwitterOrGroup(parts, "", q.AnyKeywords, quoteTwitterTerm)
+	parts = appendTwitterOrGroup(parts, "#", q.Hashtags, func(s string) string { return strings.TrimPrefix(s, "#") })
+	parts = appendTwitterOrGroup(parts, "@", q.Mentions, trimAt)
+	parts = appendTwitterOrGroup(parts, "from:", q.Authors, trimAt)
+	parts = appendTwitterOrGroup(parts, "to:", q.ReplyTo, trimAt)
+	parts = appendTwitterOrGroup(parts, "lang:", q.Languages, strings.ToLower)
+
+	for _, kw := range q.ExcludeKeywords {
+		parts = append(parts, "-"+quoteTwitterTerm(kw))
+	}
+
+	flags := []struct {
+		set      bool
+		operator string
+	}{
+		{q.ExcludeRetweets, "-is:retweet"},
+		{q.ExcludeReplies, "-is:reply"},
+		{q.ExcludeQuotes, "-is:quote"},
+		{q.HasMedia, "has:media"},
+		{q.HasImages, "has:images"},
+		{q.HasVideos, "has:videos"},
+		{q.HasLinks, "has:links"},
+	}
+	for _, flag := range flags {
+		if flag.set {
+			parts = append(parts, flag.operator)
+		}
+	}
+
+	mins := []struct {
+		value    uint
+		operator string
+	}{
+		{q.MinLikes, "min_faves:"},
+		{q.MinRetweets, "min_retweets:"},
+		{q.MinReplies, "min_replies:"},
+	}
+	for _, m := range mins {
+		if m.value > 0 {
+			parts = append(parts, m.operator+strconv.FormatUint(uint64(m.value), 10))
+		}
+	}
+
+	if !q.Since.IsZero() {
+		parts = append(parts, "since:"+formatTwitterQueryTime(q.Since))
+	}
+	if !q.Until.IsZero() {
+		parts = append(parts, "until:"+formatTwitterQueryTime(q.Until))
+	}
+
+	parts = append(parts, q.Extra...)
+
+	return strings.Join(parts, " ")
+}
+
+// Validate validates the query fields, returning one ValidationError per problem
+func (q TwitterQuery) Validate() error {
+	var errs []error
+
+	if q.String() == "" {
+		return NewValidationError("query", CodeRequired, nil, ErrTwitterQueryEmpty)
+	}
+
+	checkTerms := func(field string, terms []string) {
+		for i, term := range terms {
+			if strings.TrimSpace(term) == "" || strings.Contains(term, `"`) {
+				errs = append(errs, NewValidationError(fmt.Sprintf("%s[%d]", field, i), CodeInvalidFormat, term, ErrTwitterQueryInvalidTerm))
+			}
+		}
+	}
+	checkRegex := func(field string, values []string, re *regexp.Regexp, err error) {
+		for i, v := range values {
+			if !re.MatchString(v) {
+				errs = append(errs, NewValidationError(fmt.Sprintf("%s[%d]", field, i), CodeInvalidFormat, v, err))
+			}
+		}
+	}
+
+	checkTerms("keywords", q.Keywords)
+	checkTerms("any_keywords", q.AnyKeywords)
+	checkTerms("exclude_keywords", q.ExcludeKeywords)
+	checkRegex("authors", q.Authors, twitterUsernameRegex, ErrTwitterQueryInvalidUsername)
+	checkRegex("reply_to", q.ReplyTo, twitterUsernameRegex, ErrTwitterQueryInvalidUsername)
+	checkRegex("mentions", q.Mentions, twitterUsernameRegex, ErrTwitterQueryInvalidUsername)
+	checkRegex("hashtags", q.Hashtags, twitterHashtagRegex, ErrTwitterQueryInvalidHashtag)
+	checkRegex("languages", q.Languages, twitterLanguageRegex, ErrTwitterQueryInvalidLanguage)
+
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		errs = append(errs, NewValidationError("until", CodeOutOfRange, q.Until, ErrTwitterQueryInvalidRange))
+	}
+
+	return errors.Join(errs...)
+}
+
+// ParseTwitterQuery parses a query written in the Twitter search operator syntax. As in Twitter search, implicit AND
+// binds tighter than OR, so "a b OR c" means "(a b) OR c".
+// Operators that TwitterQuery does not model, or that cannot be represented without changing the meaning of the query,
+// are kept verbatim in Extra, so that rendering the result yields an equivalent query.
+func ParseTwitterQuery(query string) (*TwitterQuery, error) {
+	tokens, err := lexTwitterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &twitterQueryParser{tokens: tokens}
+	expr, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, fmt.Errorf("%w: unexpected '%s'", ErrTwitterQuerySyntax, p.tokens[p.pos])
+	}
+
+	q := &TwitterQuery{}
+	filled := make(map[string]bool)
+	if err := q.addExpr(expr, filled); err != nil {
+		return nil, err
+	}
+	return q, nil
+}
+
+// quoteTwitterTerm quotes a search term if it would otherwise be interpreted as an operator or split in several terms.
+// Double quotes cannot be escaped in Twitter queries, so they are removed.
+func quoteTwitterTerm(term string) string {
+	term = strings.ReplaceAll(term, `"`, "")
+	needsQuotes := term == "" || strings.EqualFold(term, "OR") || strings.EqualFold(term, "AND") ||
+		strings.ContainsAny(term, "():") || strings.ContainsAny(term[:1], "-#@$") ||
+		strings.ContainsFunc(term, unicode.IsSpace)
+	if needsQuotes {
+		return `"` + term + `"`
+	}
+	return term
+}
+
+func trimAt(s string) string {
+	return strings.TrimPrefix(s, "@")
+}
+
+func appendTwitterOrGroup(parts []string, operator string, values []string, normalize func(string) string) []string {
+	switch len(values) {
+	case 0:
+		return parts
+	case 1:
+		return append(parts, operator+normalize(values[0]))
+	}
+
+	terms := make([]string, len(values))
+	for i, v := range values {
+		terms[i] = operator + normalize(v)
+	}
+	return append(parts, "("+strings.Join(terms, " OR ")+")")
+}
+
+func formatTwitterQueryTime(t time.Time) string {
+	t = t.UTC()
+	if t.Equal(t.Truncate(24 * time.Hour)) {
+		return t.Format(twitterQueryDateFormat)
+	}
+	return t.Format(twitterQueryDateTimeFormat)
+}
+
+// parseTwitterQueryTime parses a since: or until: value. Only UTC is accepted as a time zone, since time.Parse treats
+// other abbreviations (e.g. PST) as a zero offset.
+func parseTwitterQueryTime(s string) (time.Time, error) {
+	if t, err := time.Parse(twitterQueryDateFormat, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(twitterQueryDateTimeFormat, s); err == nil {
+		if !strings.HasSuffix(s, "_UTC") {
+			return time.Time{}, fmt.Errorf("%w '%s', the time zone must be UTC", ErrTwitterQueryInvalidDate, s)
+		}
+		return t.UTC(), nil
+	}
+	return time.Time{}, fmt.Errorf("%w '%s'", ErrTwitterQueryInvalidDate, s)
+}
+
+// Lexer
+
+type twitterQueryTokenKind int
+
+const (
+	twitterTokenTerm twitterQueryTokenKind = iota
+	twitterTokenOpen
+	twitterTokenClose
+	twitterTokenOr
+)
+
+type twitterQueryToken struct {
+	kind    twitterQueryTokenKind
+	text    string // the term without quotes or leading '-'
+	quoted  bool
+	negated bool // for terms and opening parentheses
+}
+
+func (t twitterQueryToken) String() string {
+	switch t.kind {
+	case twitterTokenOpen:
+		if t.negated {
+			return "-("
+		}
+		return "("
+	case twitterTokenClose:
+		return ")"
+	case twitterTokenOr:
+		return "OR"
+	}
+
+	ret := t.text
+	if t.quoted {
+		ret = `"` + ret + `"`
+	}
+	if t.negated {
+		ret = "-" + ret
+	}
+	return ret
+}
+
+func lexTwitterQuery(query string) ([]twitterQueryToken, error) {
+	var tokens []twitterQueryToken
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, twitterQueryToken{kind: twitterTokenOpen})
+			i++
+		case r == ')':
+			tokens = append(tokens, twitterQueryToken{kind: twitterTokenClose})
+			i++
+		default:
+			tok := twitterQueryToken{kind: twitterTokenTerm}
+			if r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]) {
+				tok.negated = true
+				i++
+			}
+
+			switch runes[i] {
+			case '(':
+				tokens = append(tokens, twitterQueryToken{kind: twitterTokenOpen, negated: true})
+				i++
+				continue
+			case ')':
+				return nil, fmt.Errorf("%w: '-' must be followed by a term or a group", ErrTwitterQuerySyntax)
+			}
+
+			if runes[i] == '"' {
+				end := i + 1
+				for end < len(runes) && runes[end] != '"' {
+					end++
+				}
+				if end >= len(runes) {
+					return nil, fmt.Errorf("%w: unterminated quote", ErrTwitterQuerySyntax)
+				}
+				tok.quoted = true
+				tok.text = string(runes[i+1 : end])
+				i = end + 1
+				if strings.TrimSpace(tok.text) == "" {
+					return nil, fmt.Errorf("%w: empty quoted term", ErrTwitterQuerySyntax)
+				}
+			} else {
+				start := i
+				for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+					i++
+				}
+				tok.text = string(runes[start:i])
+			}
+
+			switch {
+			case !tok.quoted && !tok.negated && tok.text == "OR":
+				tok.kind = twitterTokenOr
+			case !tok.quoted && !tok.negated && tok.text == "AND":
+				// AND is implicit, so it can be dropped
+				continue
+			}
+			tokens = append(tokens, tok)
+		}
+	}
+
+	return tokens, nil
+}
+
+// Parser
+
+// twitterQueryExpr is a disjunction (OR) of conjunctions (implicit AND) of nodes
+type twitterQueryExpr struct {
+	disjuncts [][]twitterQueryNode
+}
+
+// twitterQueryNode is either a single term or a parenthesized expression, which may be negated as a whole
+type twitterQueryNode struct {
+	term    *twitterQueryToken
+	group   *twitterQueryExpr
+	negated bool
+}
+
+func (n twitterQueryNode) String() string {
+	if n.term != nil {
+		return n.term.String()
+	}
+	if n.negated {
+		return "-(" + n.group.String() + ")"
+	}
+	return "(" + n.group.String() + ")"
+}
+
+func (e twitterQueryExpr) String() string {
+	disjuncts := make([]string, len(e.disjuncts))
+	for i, conjunct := range e.disjuncts {
+		nodes := make([]string, len(conjunct))
+		for j, node := range conjunct {
+			nodes[j] = node.String()
+		}
+		disjuncts[i] = strings.Join(nodes, " ")
+	}
+	return strings.Join(disjuncts, " OR ")
+}
+
+type twitterQueryParser struct {
+	tokens []twitterQueryToken
+	pos    int
+}
+
+func (p *twitterQueryParser) peek() (twitterQueryToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return twitterQueryToken{}, false
+	}
+	return p.tokens[p.pos], true
+}
+
+// parseExpr parses an expression until the end of the input or a closing parenthesis
+func (p *twitterQueryParser) parseExpr() (twitterQueryExpr, error) {
+	var expr twitterQueryExpr
+	for {
+		var conjunct []twitterQueryNode
+		for {
+			tok, ok := p.peek()
+			if !ok || tok.kind == twitterTokenClose || tok.kind == twitterTokenOr {
+				break
+			}
+			node, err := p.parsePrimary()
+			if err != nil {
+				return twitterQueryExpr{}, err
+			}
+			conjunct = append(conjunct, node)
+		}
+
+		tok, ok := p.peek()
+		isOr := ok && tok.kind == twitterTokenOr
+		if len(conjunct) == 0 {
+			if isOr || len(expr.disjuncts) > 0 {
+				return twitterQueryExpr{}, fmt.Errorf("%w: OR must be between two terms", ErrTwitterQuerySyntax)
+			}
+			return expr, nil
+		}
+		expr.disjuncts = append(expr.disjuncts, conjunct)
+		if !isOr {
+			return expr, nil
+		}
+		p.pos++
+	}
+}
+
+func (p *twitterQueryParser) parsePrimary() (twitterQueryNode, error) {
+	tok, ok := p.peek()
+	if !ok {
+		return twitterQueryNode{}, fmt.Errorf("%w: unexpected end of query", ErrTwitterQuerySyntax)
+	}
+
+	switch tok.kind {
+	case twitterTokenTerm:
+		p.pos++
+		return twitterQueryNode{term: &tok}, nil
+	case twitterTokenOpen:
+		p.pos++
+		group, err := p.parseExpr()
+		if err != nil {
+			return twitterQueryNode{}, err
+		}
+		if tok, ok := p.peek(); !ok || tok.kind != twitterTokenClose {
+			return twitterQueryNode{}, fmt.Errorf("%w: missing ')'", ErrTwitterQuerySyntax)
+		}
+		p.pos++
+		if len(group.disjuncts) == 0 {
+			return twitterQueryNode{}, fmt.Errorf("%w: empty group", ErrTwitterQuerySyntax)
+		}
+		return twitterQueryNode{group: &group, negated: tok.negated}, nil
+	default:
+		return twitterQueryNode{}, fmt.Errorf("%w: unexpected '%s'", ErrTwitterQuerySyntax, tok)
+	}
+}
+
+// Mapping to TwitterQuery
+
+type twitterTermKind int
+
+const (
+	twitterTermOther twitterTermKind = iota
+	twitterTermKeyword
+	twitterTermAuthor
+	twitterTermReplyTo
+	twitterTermMention
+	twitterTermHashtag
+	twitterTermLanguage
+)
+
+// classifyTwitterTerm returns the kind of a non-negated term and its value without the operator
+func classifyTwitterTerm(tok twitterQueryToken) (twitterTermKind, string) {
+	if tok.negated {
+		return twitterTermOther, ""
+	}
+	if tok.quoted {
+		return twitterTermKeyword, tok.text
+	}
+
+	prefixes := []struct {
+		prefix string
+		kind   twitterTermKind
+	}{
+		{"from:", twitterTermAuthor},
+		{"to:", twitterTermReplyTo},
+		{"lang:", twitterTermLanguage},
+		{"@", twitterTermMention},
+		{"#", twitterTermHashtag},
+	}
+	for _, p := range prefixes {
+		if value, ok := strings.CutPrefix(tok.text, p.prefix); ok && value != "" {
+			return p.kind, value
+		}
+	}
+
+	if strings.ContainsAny(tok.text, ":$") {
+		return twitterTermOther, ""
+	}
+	return twitterTermKeyword, tok.text
+}
+
+// addExpr maps an expression to the query fields. A conjunction is mapped node by node, while a disjunction is
+// mapped as a whole, since the fields are combined with AND.
+func (q *TwitterQuery) addExpr(expr twitterQueryExpr, filled map[string]bool) error {
+	switch len(expr.disjuncts) {
+	case 0:
+		return nil
+	case 1:
+		for _, node := range expr.disjuncts[0] {
+			if err := q.addNode(node, filled); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// An OR group can only be mapped if all its alternatives are plain terms of the same kind
+	verbatim := "(" + expr.String() + ")"
+	var kind twitterTermKind
+	values := make([]string, 0, len(expr.disjuncts))
+	for i, conjunct := range expr.disjuncts {
+		if len(conjunct) != 1 || conjunct[0].term == nil {
+			q.Extra = append(q.Extra, verbatim)
+			return nil
+		}
+		k, v := classifyTwitterTerm(*conjunct[0].term)
+		if k == twitterTermOther || (i > 0 && k != kind) {
+			q.Extra = append(q.Extra, verbatim)
+			return nil
+		}
+		kind = k
+		values = append(values, v)
+	}
+
+	var target *[]string
+	var key string
+	switch kind {
+	case twitterTermKeyword:
+		target, key = &q.AnyKeywords, "any_keywords"
+	default:
+		target, key = q.listFor(kind)
+	}
+	if filled[key] {
+		q.Extra = append(q.Extra, verbatim)
+		return nil
+	}
+	filled[key] = true
+	*target = values
+	return nil
+}
+
+// addNode maps a term or a group to the query fields. Redundant parentheses are flattened, while negated groups are
+// kept as they are, since TwitterQuery cannot represent them.
+func (q *TwitterQuery) addNode(node twitterQueryNode, filled map[string]bool) error {
+	if node.term != nil {
+		handled, err := q.addTerm(*node.term, filled)
+		if err != nil || handled {
+			return err
+		}
+		q.Extra = append(q.Extra, node.String())
+		return nil
+	}
+
+	if node.negated {
+		q.Extra = append(q.Extra, node.String())
+		return nil
+	}
+	return q.addExpr(*node.group, filled)
+}
+
+// listFor returns the field a single-valued term of the given kind is stored in
+func (q *TwitterQuery) listFor(kind twitterTermKind) (*[]string, string) {
+	switch kind {
+	case twitterTermAuthor:
+		return &q.Authors, "authors"
+	case twitterTermReplyTo:
+		return &q.ReplyTo, "reply_to"
+	case twitterTermMention:
+		return &q.Mentions, "mentions"
+	case twitterTermHashtag:
+		return &q.Hashtags, "hashtags"
+	case twitterTermLanguage:
+		return &q.Languages, "languages"
+	default:
+		return &q.Keywords, "keywords"
+	}
+}
+
+// addTerm maps a single term to the query fields, returning false if the term must be kept verbatim
+func (q *TwitterQuery) addTerm(tok twitterQueryToken, filled map[string]bool) (bool, error) {
+	if tok.negated {
+		switch {
+		case tok.quoted || !strings.Contains(tok.text, ":"):
+			q.ExcludeKeywords = append(q.ExcludeKeywords, tok.text)
+		case tok.text == "is:retweet":
+			q.ExcludeRetweets = true
+		case tok.text == "is:reply":
+			q.ExcludeReplies = true
+		case tok.text == "is:quote":
+			q.ExcludeQuotes = true
+		default:
+			return false, nil
+		}
+		return true, nil
+	}
+
+	if !tok.quoted {
+		switch tok.text {
+		case "has:media":
+			q.HasMedia = true
+			return true, nil
+		case "has:images":
+			q.HasImages = true
+			return true, nil
+		case "has:videos":
+			q.HasVideos = true
+			return true, nil
+		case "has:links":
+			q.HasLinks = true
+			return true, nil
+		}
+
+		if operator, value, ok := strings.Cut(tok.text, ":"); ok {
+			switch operator {
+			case "since", "until":
+				t, err := parseTwitterQueryTime(value)
+				if err != nil {
+					return false, err
+				}
+				if operator == "since" {
+					q.Since = t
+				} else {
+					q.Until = t
+				}
+				return true, nil
+			case "min_faves", "min_retweets", "min_replies":
+				n, err := strconv.ParseUint(value, 10, 0)
+				if err != nil {
+					return false, nil
+				}
+				switch operator {
+				case "min_faves":
+					q.MinLikes = uint(n)
+				case "min_retweets":
+					q.MinRetweets = uint(n)
+				default:
+					q.MinReplies = uint(n)
+				}
+				return true, nil
+			}
+		}
+	}
+
+	kind, value := classifyTwitterTerm(tok)
+	switch kind {
+	case twitterTermOther:
+		return false, nil
+	case twitterTermKeyword:
+		q.Keywords = append(q.Keywords, value)
+		return true, nil
+	}
+
+	// Repeating a single-valued filter means AND, which cannot be expressed with the OR semantics of the lists
+	target, key := q.listFor(kind)
+	if filled[key] {
+		return false, nil
+	}
+	filled[key] = true
+	*target = []string{value}
+	return true, nil
+}
@@ -0,0 +1,189 @@
+package args_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("TwitterQuery", func() {
+	Describe("String", func() {
+		It("should render all the filters", func() {
+			q := args.TwitterQuery{
+				Keywords:        []string{"golang", "open source"},
+				AnyKeywords:     []string{"tee", "enclave"},
+				ExcludeKeywords: []string{"spam"},
+				Authors:         []string{"@masa_finance", "golang"},
+				ReplyTo:         []string{"elonmusk"},
+				Mentions:        []string{"jack"},
+				Hashtags:        []string{"#ai"},
+				Languages:       []string{"EN", "es"},
+				Since:           time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				Until:           time.Date(2025, 1, 3, 12, 30, 0, 0, time.UTC),
+				ExcludeRetweets: true,
+				HasMedia:        true,
+				MinLikes:        100,
+			}
+			Expect(q.String()).To(Equal(`golang "open source" (tee OR enclave) #ai @jack (from:masa_finance OR from:golang) to:elonmusk (lang:en OR lang:es) -spam -is:retweet has:media min_faves:100 since:2025-01-02 until:2025-01-03_12:30:00_UTC`))
+		})
+
+		It("should quote terms that look like operators", func() {
+			q := args.TwitterQuery{Keywords: []string{"OR", "-minus", "a:b", `say "hi"`}}
+			Expect(q.String()).To(Equal(`"OR" "-minus" "a:b" "say hi"`))
+		})
+	})
+
+	Describe("Validate", func() {
+		It("should accept a valid query", func() {
+			q := args.TwitterQuery{Authors: []string{"masa_finance"}, Languages: []string{"en"}}
+			Expect(q.Validate()).To(Succeed())
+		})
+
+		It("should reject an empty query", func() {
+			Expect(errors.Is(args.TwitterQuery{}.Validate(), args.ErrTwitterQueryEmpty)).To(BeTrue())
+		})
+
+		It("should report every invalid field", func() {
+			q := args.TwitterQuery{
+				Authors:   []string{"ok", "not a user"},
+				Languages: []string{"english"},
+				Since:     time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				Until:     time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			}
+			fields := []string{}
+			for _, ve := range args.ValidationErrors(q.Validate()) {
+				fields = append(fields, ve.Field)
+			}
+			Expect(fields).To(ConsistOf("authors[1]", "languages[0]", "until"))
+		})
+	})
+
+	Describe("ParseTwitterQuery", func() {
+		It("should parse a rendered query back into the same structure", func() {
+			q := args.TwitterQuery{
+				Keywords:        []string{"golang", "open source"},
+				AnyKeywords:     []string{"tee", "enclave"},
+				ExcludeKeywords: []string{"spam"},
+				Authors:         []string{"masa_finance", "golang"},
+				Hashtags:        []string{"ai"},
+				Languages:       []string{"en"},
+				Since:           time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC),
+				ExcludeRetweets: true,
+				ExcludeReplies:  true,
+				HasVideos:       true,
+				MinRetweets:     5,
+			}
+			parsed, err := args.ParseTwitterQuery(q.String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*parsed).To(Equal(q))
+		})
+
+		It("should parse unparenthesized OR groups", func() {
+			parsed, err := args.ParseTwitterQuery("from:a OR from:b")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Authors).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should give implicit AND precedence over OR", func() {
+			parsed, err := args.ParseTwitterQuery("a b OR c")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Keywords).To(BeEmpty())
+			Expect(parsed.AnyKeywords).To(BeEmpty())
+			Expect(parsed.String()).To(Equal("(a b OR c)"))
+
+			parsed, err = args.ParseTwitterQuery("from:a OR from:b lang:en")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Authors).To(BeEmpty())
+			Expect(parsed.Extra).To(Equal([]string{"(from:a OR from:b lang:en)"}))
+
+			parsed, err = args.ParseTwitterQuery("x (a b OR c) lang:en")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Keywords).To(Equal([]string{"x"}))
+			Expect(parsed.Languages).To(Equal([]string{"en"}))
+			Expect(parsed.Extra).To(Equal([]string{"(a b OR c)"}))
+
+			reparsed, err := args.ParseTwitterQuery(parsed.String())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(*reparsed).To(Equal(*parsed))
+		})
+
+		It("should flatten redundant parentheses", func() {
+			parsed, err := args.ParseTwitterQuery("((a b)) ((#x OR #y))")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Keywords).To(Equal([]string{"a", "b"}))
+			Expect(parsed.Hashtags).To(Equal([]string{"x", "y"}))
+		})
+
+		It("should only accept UTC times", func() {
+			parsed, err := args.ParseTwitterQuery("a since:2025-01-02_10:00:00_UTC")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Since).To(Equal(time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)))
+
+			_, err = args.ParseTwitterQuery("a since:2025-01-02_10:00:00_PST")
+			Expect(errors.Is(err, args.ErrTwitterQueryInvalidDate)).To(BeTrue())
+		})
+
+		It("should keep unsupported operators verbatim", func() {
+			parsed, err := args.ParseTwitterQuery(`bitcoin is:verified (from:a OR #b) -url:example.com #x #y`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Keywords).To(Equal([]string{"bitcoin"}))
+			Expect(parsed.Hashtags).To(Equal([]string{"x"}))
+			Expect(parsed.Extra).To(Equal([]string{"is:verified", "(from:a OR #b)", "-url:example.com", "#y"}))
+		})
+
+		It("should keep negated groups negated", func() {
+			parsed, err := args.ParseTwitterQuery("-(a OR b) c")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Keywords).To(Equal([]string{"c"}))
+			Expect(parsed.AnyKeywords).To(BeEmpty())
+			Expect(parsed.Extra).To(Equal([]string{"-(a OR b)"}))
+			Expect(parsed.String()).To(Equal("c -(a OR b)"))
+
+			parsed, err = args.ParseTwitterQuery("-((from:a))")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Authors).To(BeEmpty())
+			Expect(parsed.String()).To(Equal("-((from:a))"))
+		})
+
+		It("should parse negated phrases as excluded keywords", func() {
+			parsed, err := args.ParseTwitterQuery(`bitcoin -"price prediction"`)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Keywords).To(Equal([]string{"bitcoin"}))
+			Expect(parsed.ExcludeKeywords).To(Equal([]string{"price prediction"}))
+			Expect(parsed.String()).To(Equal(`bitcoin -"price prediction"`))
+		})
+
+		It("should reject invalid syntax", func() {
+			for _, query := range []string{`"unterminated`, "(a OR b", "a)", "a OR", "OR a", "a OR OR b", "()", "since:yesterday", "-(a", "a -)", `-""`, `a "  "`} {
+				_, err := args.ParseTwitterQuery(query)
+				Expect(err).To(HaveOccurred(), query)
+			}
+		})
+	})
+
+	Describe("TwitterSearchArguments", func() {
+		It("should accept queries that the parser cannot handle", func() {
+			for _, query := range []string{`"unbalanced`, "since:yesterday", "(a OR b"} {
+				twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapSearchByQuery), Query: query}
+				Expect(twitterArgs.Validate()).To(Succeed(), query)
+
+				_, err := twitterArgs.ParseQuery()
+				Expect(err).To(HaveOccurred(), query)
+			}
+		})
+
+		It("should set the query from a builder", func() {
+			twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapSearchByQuery)}
+			twitterArgs.SetQuery(args.TwitterQuery{Authors: []string{"masa_finance"}, ExcludeRetweets: true})
+			Expect(twitterArgs.Query).To(Equal("from:masa_finance -is:retweet"))
+			parsed, err := twitterArgs.ParseQuery()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(parsed.Authors).To(Equal([]string{"masa_finance"}))
+		})
+	})
+})
@@ -0,0 +1,174 @@
+package args_test
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("TwitterSearchArguments", func() {
+	Describe("Time range", func() {
+		It("should parse RFC3339 and date-only times into UTC", func() {
+			var twitterArgs args.TwitterSearchArguments
+			jsonData := []byte(`{"type":"searchbyfullarchive","query":"golang","start_time":"2020-01-02","end_time":"2020-01-03T10:00:00+02:00"}`)
+			Expect(json.Unmarshal(jsonData, &twitterArgs)).To(Succeed())
+			Expect(twitterArgs.HasTimeRange()).To(BeTrue())
+			Expect(twitterArgs.GetStartTime()).To(Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)))
+			Expect(twitterArgs.GetEndTime()).To(Equal(time.Date(2020, 1, 3, 8, 0, 0, 0, time.UTC)))
+		})
+
+		It("should parse the times of arguments built in code", func() {
+			twitterArgs := args.TwitterSearchArguments{QueryType: string(types.CapSearchByQuery), StartTime: "2026-10-16T00:00:00Z"}
+			Expect(twitterArgs.HasTimeRange()).To(BeTrue())
+			Expect(twitterArgs.GetStartTime()).To(Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)))
+
+			twitterArgs.EndTime = "tomorrow"
+			_, err := twitterArgs.GetEndTime()
+			Expect(errors.Is(err, args.ErrTwitterTimeInvalid)).To(BeTrue())
+		})
+
+		It("should leave the times unset when not provided", func() {
+			var twitterArgs args.TwitterSearchArguments
+			Expect(json.Unmarshal([]byte(`{"type":"searchbyquery","query":"golang"}`), &twitterArgs)).To(Succeed())
+			Expect(twitterArgs.HasTimeRange()).To(BeFalse())
+			start, err := twitterArgs.GetStartTime()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(start.IsZero()).To(BeTrue())
+		})
+
+		It("should reject malformed times", func() {
+			var twitterArgs args.TwitterSearchArguments
+			err := json.Unmarshal([]byte(`{"type":"searchbyquery","start_time":"yesterday"}`), &twitterArgs)
+			Expect(errors.Is(err, args.ErrTwitterTimeInvalid)).To(BeTrue())
+		})
+
+		It("should reject an end time before the start time", func() {
+			twitterArgs := &args.TwitterSearchArguments{
+				QueryType: string(types.CapSearchByFullArchive),
+				StartTime: "2020-01-03",
+				EndTime:   "2020-01-02",
+			}
+			Expect(errors.Is(twitterArgs.Validate(), args.ErrTwitterEndTimeBeforeStart)).To(BeTrue())
+		})
+
+		It("should reject a start time in the future", func() {
+			twitterArgs := &args.TwitterSearchArguments{
+				QueryType: string(types.CapSearchByFullArchive),
+				StartTime: time.Now().Add(time.Hour).Format(time.RFC3339),
+			}
+			Expect(errors.Is(twitterArgs.ValidateForJobType(types.TwitterJob), args.ErrTwitterStartTimeInTheFuture)).To(BeTrue())
+
+			twitterArgs.StartTime = time.Now().UTC().Format("2006-01-02")
+			Expect(twitterArgs.ValidateForJobType(types.TwitterJob)).To(Succeed())
+		})
+
+		It("should enforce the 7-day window for searchbyquery only", func() {
+			start := time.Now().Add(-30 * 24 * time.Hour).Format(time.RFC3339)
+			twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapSearchByQuery), StartTime: start}
+			Expect(errors.Is(twitterArgs.ValidateForJobType(types.TwitterJob), args.ErrTwitterTimeOutsideQueryRange)).To(BeTrue())
+
+			twitterArgs.QueryType = string(types.CapSearchByFullArchive)
+			Expect(twitterArgs.ValidateForJobType(types.TwitterJob)).To(Succeed())
+
+			twitterArgs.QueryType = string(types.CapSearchByQuery)
+			twitterArgs.StartTime = time.Now().Add(-24 * time.Hour).Format(time.RFC3339)
+			Expect(twitterArgs.ValidateForJobType(types.TwitterJob)).To(Succeed())
+		})
+
+		It("should compare date-only times at day granularity", func() {
+			twitterArgs := &args.TwitterSearchArguments{
+				QueryType: string(types.CapSearchByQuery),
+				StartTime: time.Now().UTC().Add(-args.TwitterSearchByQueryWindow).Format("2006-01-02"),
+			}
+			Expect(twitterArgs.ValidateForJobType(types.TwitterJob)).To(Succeed())
+
+			twitterArgs.StartTime = time.Now().UTC().Add(-args.TwitterSearchByQueryWindow - 24*time.Hour).Format("2006-01-02")
+			Expect(errors.Is(twitterArgs.ValidateForJobType(types.TwitterJob), args.ErrTwitterTimeOutsideQueryRange)).To(BeTrue())
+		})
+
+		It("should not check the time window when decoding", func() {
+			var twitterArgs args.TwitterSearchArguments
+			Expect(json.Unmarshal([]byte(`{"type":"searchbyquery","query":"golang","start_time":"2020-01-01"}`), &twitterArgs)).To(Succeed())
+			Expect(errors.Is(twitterArgs.ValidateForJobType(types.TwitterJob), args.ErrTwitterTimeOutsideQueryRange)).To(BeTrue())
+		})
+
+		It("should apply the window to the default capability", func() {
+			_, err := args.UnmarshalJobArguments(types.TwitterJob, map[string]any{
+				"query":      "golang",
+				"start_time": "2020-01-01",
+			})
+			Expect(errors.Is(err, args.ErrTwitterTimeOutsideQueryRange)).To(BeTrue())
+		})
+	})
+
+	Describe("getspace", func() {
+		It("should accept a well-formed Space ID", func() {
+			var twitterArgs args.TwitterSearchArguments
+			Expect(json.Unmarshal([]byte(`{"type":"getspace","query":"1DXxyRYNejbKM"}`), &twitterArgs)).To(Succeed())
+			Expect(twitterArgs.IsSingleSpaceOperation()).To(BeTrue())
+		})
+
+		It("should reject a missing Space ID", func() {
+			var twitterArgs args.TwitterSearchArguments
+			err := json.Unmarshal([]byte(`{"type":"getspace"}`), &twitterArgs)
+			Expect(errors.Is(err, args.ErrTwitterSpaceIDRequired)).To(BeTrue())
+		})
+
+		It("should reject a malformed Space ID", func() {
+			for _, query := range []string{"1DXxyRYNejbK", "https://x.com/i/spaces/1DXxyRYNejbKM", "1DXxyRYNej-KM"} {
+				twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapGetSpace), Query: query}
+				err := twitterArgs.Validate()
+				Expect(errors.Is(err, args.ErrTwitterSpaceIDInvalid)).To(BeTrue(), query)
+				Expect(args.ValidationErrors(err)[0].Field).To(Equal("query"))
+			}
+		})
+
+		It("should not apply the Space ID format to other capabilities", func() {
+			twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapSearchByProfile), Query: "masa_finance"}
+			Expect(twitterArgs.Validate()).To(Succeed())
+		})
+
+		It("should describe the Space ID in the schema", func() {
+			schema, err := args.GenerateSchema(types.TwitterJob, types.CapGetSpace)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(schema.Required).To(ContainElement("query"))
+			Expect(schema.Property("query").Pattern).NotTo(BeEmpty())
+		})
+	})
+
+	Describe("gettrends", func() {
+		It("should resolve the location by WOEID or name", func() {
+			var twitterArgs args.TwitterSearchArguments
+			Expect(json.Unmarshal([]byte(`{"type":"gettrends","location":"23424977"}`), &twitterArgs)).To(Succeed())
+			Expect(twitterArgs.GetTrendLocation().Name).To(Equal("United States"))
+
+			twitterArgs = args.TwitterSearchArguments{QueryType: string(types.CapGetTrends), Location: "london"}
+			Expect(twitterArgs.Validate()).To(Succeed())
+			Expect(twitterArgs.GetTrendLocation().WOEID).To(Equal(44418))
+		})
+
+		It("should default to worldwide trends", func() {
+			twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapGetTrends)}
+			Expect(twitterArgs.Validate()).To(Succeed())
+			Expect(twitterArgs.GetTrendLocation().WOEID).To(Equal(types.WorldwideWOEID))
+		})
+
+		It("should reject unknown locations", func() {
+			twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapGetTrends), Location: "Atlantis"}
+			err := twitterArgs.Validate()
+			Expect(errors.Is(err, args.ErrTwitterLocationUnknown)).To(BeTrue())
+			Expect(args.ValidationErrors(err)[0].Code).To(Equal(args.CodeInvalidValue))
+		})
+
+		It("should reject a location for other capabilities", func() {
+			twitterArgs := &args.TwitterSearchArguments{QueryType: string(types.CapSearchByQuery), Query: "golang", Location: "1"}
+			Expect(errors.Is(twitterArgs.Validate(), args.ErrTwitterLocationNotAllowed)).To(BeTrue())
+		})
+	})
+})
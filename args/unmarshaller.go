@@ -3,7 +3,6 @@ package args
 import (
 	"encoding/json"
 	"fmt"
-	"strings"
 
 	"github.com/masa-finance/tee-types/types"
 )
@@ -15,149 +14,63 @@ type JobArguments interface {
 
 // UnmarshalJobArguments unmarshals job arguments from a generic map into the appropriate typed struct
 // This works with both tee-indexer and tee-worker JobArguments types
+// The concrete type is resolved through the DefaultRegistry, see Register to add new job types
 func UnmarshalJobArguments(jobType types.JobType, args map[string]any) (JobArguments, error) {
-	switch jobType {
-	case types.WebJob:
-		return unmarshalWebArguments(args)
-
-	case types.TiktokJob:
-		return unmarshalTikTokArguments(args)
-
-	case types.TwitterJob, types.TwitterCredentialJob, types.TwitterApiJob, types.TwitterApifyJob:
-		return unmarshalTwitterArguments(jobType, args)
-
-	case types.LinkedInJob:
-		return unmarshalLinkedInArguments(jobType, args)
-
-	case types.RedditJob:
-		return unmarshalRedditArguments(jobType, args)
-
-	case types.TelemetryJob:
-		return &TelemetryJobArguments{}, nil
-
-	default:
-		return nil, fmt.Errorf("unknown job type: %s", jobType)
-	}
-}
-
-// Helper functions for unmarshaling specific argument types
-func unmarshalWebArguments(args map[string]any) (*WebArguments, error) {
-	webArgs := &WebArguments{}
-	if err := unmarshalToStruct(args, webArgs); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal web job arguments: %w", err)
-	}
-	return webArgs, nil
+	return DefaultRegistry.Unmarshal(jobType, args)
 }
 
-func unmarshalTikTokArguments(args map[string]any) (JobArguments, error) {
-	// Unmarshal minimally to read QueryType like we do for Twitter
-	minimal := &QueryTypeArgument{}
-	if err := unmarshalToStruct(args, minimal); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal TikTok arguments: %w", err)
-	}
-	capability := types.Capability(strings.ToLower(minimal.QueryType))
-	if capability == types.CapEmpty {
-		defaultCap, exists := types.JobDefaultCapabilityMap[types.TiktokJob]
-		if !exists {
-			return nil, fmt.Errorf("no default capability configured for job type: %s", types.TiktokJob)
-		}
-		capability = defaultCap
-	}
+// newDefaultRegistry returns a Registry with all the built-in job arguments registered
+func newDefaultRegistry() *Registry {
+	r := NewRegistry()
 
-	switch capability {
-	case types.CapSearchByQuery:
-		searchArgs := &TikTokSearchByQueryArguments{}
-		if err := unmarshalToStruct(args, searchArgs); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal TikTok searchbyquery arguments: %w", err)
-		}
-		if err := searchArgs.ValidateForJobType(types.TiktokJob); err != nil {
-			return nil, fmt.Errorf("tiktok job validation failed: %w", err)
-		}
-		return searchArgs, nil
-	case types.CapSearchByTrending:
-		searchArgs := &TikTokSearchByTrendingArguments{}
-		if err := unmarshalToStruct(args, searchArgs); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal TikTok searchbytrending arguments: %w", err)
-		}
-		if err := searchArgs.ValidateForJobType(types.TiktokJob); err != nil {
-			return nil, fmt.Errorf("tiktok job validation failed: %w", err)
-		}
-		return searchArgs, nil
-	case types.CapTranscription:
-		transcriptionArgs := &TikTokTranscriptionArguments{}
-		if err := unmarshalToStruct(args, transcriptionArgs); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal TikTok transcription arguments: %w", err)
-		}
-		if err := transcriptionArgs.ValidateForJobType(types.TiktokJob); err != nil {
-			return nil, fmt.Errorf("tiktok job validation failed: %w", err)
-		}
-		return transcriptionArgs, nil
-	default:
-		return nil, fmt.Errorf("unknown tiktok type: %s", capability)
+	for jobType, capability := range types.JobDefaultCapabilityMap {
+		r.SetDefaultCapability(jobType, capability)
 	}
-}
 
-func unmarshalTwitterArguments(jobType types.JobType, args map[string]any) (*TwitterSearchArguments, error) {
-	twitterArgs := &TwitterSearchArguments{}
-	if err := unmarshalToStruct(args, twitterArgs); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal Twitter job arguments: %w", err)
-	}
+	// Web
+	r.mustRegisterAll(types.WebJob, types.WebCaps, func() JobArguments { return &WebArguments{} })
 
-	// If no QueryType is specified, use the default capability for this job type
-	if twitterArgs.QueryType == "" {
-		if defaultCap, exists := types.JobDefaultCapabilityMap[jobType]; exists {
-			twitterArgs.QueryType = string(defaultCap)
-		}
-	}
+	// TikTok, each capability has its own arguments type
+	r.mustRegister(types.TiktokJob, types.CapTranscription, func() JobArguments { return &TikTokTranscriptionArguments{} })
+	r.mustRegister(types.TiktokJob, types.CapSearchByQuery, func() JobArguments { return &TikTokSearchByQueryArguments{} })
+	r.mustRegister(types.TiktokJob, types.CapSearchByTrending, func() JobArguments { return &TikTokSearchByTrendingArguments{} })
 
-	// Perform job-type-specific validation for Twitter
-	if err := twitterArgs.ValidateForJobType(jobType); err != nil {
-		return nil, fmt.Errorf("twitter job validation failed: %w", err)
+	// Twitter, all capabilities share the same arguments type
+	for _, jobType := range []types.JobType{types.TwitterJob, types.TwitterCredentialJob, types.TwitterApiJob, types.TwitterApifyJob} {
+		r.mustRegisterAll(jobType, types.JobCapabilityMap[jobType], func() JobArguments { return &TwitterSearchArguments{} })
 	}
 
-	return twitterArgs, nil
-}
+	// LinkedIn
+	r.mustRegisterAll(types.LinkedInJob, types.AlwaysAvailableLinkedInCaps, func() JobArguments { return &LinkedInArguments{} })
 
-func unmarshalLinkedInArguments(jobType types.JobType, args map[string]any) (*LinkedInArguments, error) {
-	linkedInArgs := &LinkedInArguments{}
-	if err := unmarshalToStruct(args, linkedInArgs); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal LinkedIn job arguments: %w", err)
-	}
+	// Reddit
+	r.mustRegisterAll(types.RedditJob, types.RedditCaps, func() JobArguments { return &RedditArguments{} })
 
-	// If no QueryType is specified, use the default capability for this job type
-	if linkedInArgs.QueryType == "" {
-		if defaultCap, exists := types.JobDefaultCapabilityMap[jobType]; exists {
-			linkedInArgs.QueryType = string(defaultCap)
-		}
-	}
+	// LLM
+	r.mustRegisterAll(types.LLMJob, types.LLMCaps, func() JobArguments { return &LLMProcessorArguments{} })
 
-	// Perform job-type-specific validation for LinkedIn
-	if err := linkedInArgs.ValidateForJobType(jobType); err != nil {
-		return nil, fmt.Errorf("linkedin job validation failed: %w", err)
-	}
+	// Telemetry
+	r.mustRegisterAll(types.TelemetryJob, types.AlwaysAvailableTelemetryCaps, func() JobArguments { return &TelemetryJobArguments{} })
 
-	return linkedInArgs, nil
+	return r
 }
 
-func unmarshalRedditArguments(jobType types.JobType, args map[string]any) (*RedditArguments, error) {
-	redditArgs := &RedditArguments{}
-	if err := unmarshalToStruct(args, redditArgs); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal Reddit job arguments: %w", err)
+// mustRegister registers a built-in (JobType, Capability) pair using the default validation hook
+func (r *Registry) mustRegister(jobType types.JobType, capability types.Capability, factory ArgumentsFactory) {
+	if err := r.Register(jobType, capability, factory, nil); err != nil {
+		panic(err)
 	}
+}
 
-	// If no QueryType is specified, use the default capability for this job type
-	if redditArgs.QueryType == "" {
-		if defaultCap, exists := types.JobDefaultCapabilityMap[jobType]; exists {
-			redditArgs.QueryType = types.RedditQueryType(defaultCap)
+// mustRegisterAll registers the same factory for all the given capabilities of a built-in job type
+// The empty capability is skipped, since it is always resolved to the job type's default
+func (r *Registry) mustRegisterAll(jobType types.JobType, capabilities []types.Capability, factory ArgumentsFactory) {
+	for _, capability := range capabilities {
+		if capability == types.CapEmpty {
+			continue
 		}
+		r.mustRegister(jobType, capability, factory)
 	}
-
-	// Perform job-type-specific validation for Reddit
-	if err := redditArgs.ValidateForJobType(jobType); err != nil {
-		return nil, fmt.Errorf("reddit job validation failed: %w", err)
-	}
-
-	return redditArgs, nil
 }
 
 // unmarshalToStruct converts a map[string]any to a struct using JSON marshal/unmarshal
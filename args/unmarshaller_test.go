@@ -81,6 +81,22 @@ var _ = Describe("Unmarshaller", func() {
 			})
 		})
 
+		Context("with an LLMJob", func() {
+			It("should unmarshal the arguments correctly", func() {
+				argsMap := map[string]any{
+					"dataset_id": "ds1",
+					"prompt":     "summarize: ${markdown}",
+				}
+				jobArgs, err := args.UnmarshalJobArguments(types.LLMJob, argsMap)
+				Expect(err).ToNot(HaveOccurred())
+				llmArgs, ok := jobArgs.(*args.LLMProcessorArguments)
+				Expect(ok).To(BeTrue())
+				Expect(llmArgs.QueryType).To(Equal(string(types.CapDatasetProcessor)))
+				Expect(llmArgs.DatasetId).To(Equal("ds1"))
+				Expect(llmArgs.MaxTokens).To(Equal(args.LLMDefaultMaxTokens))
+			})
+		})
+
 		Context("with a TelemetryJob", func() {
 			It("should return a TelemetryJobArguments struct", func() {
 				argsMap := map[string]any{}
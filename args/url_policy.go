@@ -0,0 +1,194 @@
+package args
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+)
+
+var (
+	ErrURLSchemeNotAllowed = errors.New("URL scheme is not allowed")
+	ErrURLHostMissing      = errors.New("URL must include a host")
+	ErrURLHostBlocked      = errors.New("URL host is blocked")
+	ErrURLAddressBlocked   = errors.New("URL points to a blocked IP address")
+	ErrURLDomainNotAllowed = errors.New("URL domain is not allowed")
+	ErrURLDomainDenied     = errors.New("URL domain is denied")
+)
+
+var (
+	// DefaultBlockedNetworks are the loopback, private, link-local (including cloud metadata endpoints such as
+	// 169.254.169.254), carrier-grade NAT, documentation, multicast and reserved ranges, and the IPv6 ranges that embed
+	// IPv4 addresses (IPv4-compatible, NAT64 and 6to4)
+	DefaultBlockedNetworks = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("100.64.0.0/10"),
+		netip.MustParsePrefix("127.0.0.0/8"),
+		netip.MustParsePrefix("169.254.0.0/16"),
+		netip.MustParsePrefix("172.16.0.0/12"),
+		netip.MustParsePrefix("192.0.0.0/24"),
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("192.168.0.0/16"),
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("224.0.0.0/4"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+		netip.MustParsePrefix("::/96"), // the unspecified and loopback addresses, and IPv4-compatible addresses
+		netip.MustParsePrefix("64:ff9b::/96"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("2002::/16"),
+		netip.MustParsePrefix("fc00::/7"),
+		netip.MustParsePrefix("fe80::/10"),
+		netip.MustParsePrefix("ff00::/8"),
+	}
+
+	// DefaultBlockedHosts are the local and cloud metadata hostnames
+	DefaultBlockedHosts = []string{
+		"localhost",
+		"localhost.localdomain",
+		"metadata",
+		"metadata.google.internal",
+		"metadata.goog",
+		"instance-data",
+		"instance-data.ec2.internal",
+	}
+
+	// DefaultAllowedSchemes are the only schemes accepted by default
+	DefaultAllowedSchemes = []string{"http", "https"}
+)
+
+// numericHostRe matches hosts made only of decimal, octal or hexadecimal labels, such as "2130706433" or "0x7f.1",
+// which some resolvers interpret as IPv4 addresses
+var numericHostRe = regexp.MustCompile(`^(0x[0-9a-f]*|[0-9]+)(\.(0x[0-9a-f]*|[0-9]+))*$`)
+
+// URLPolicy restricts the URLs that job arguments may point to, so that workers cannot be made to request internal
+// services. It only inspects the URL: hostnames are not resolved, so workers must still check the addresses they
+// connect to.
+type URLPolicy struct {
+	AllowedSchemes  []string       // Lowercase schemes, e.g. "https"
+	BlockedNetworks []netip.Prefix // IP literals in these ranges are rejected
+	BlockedHosts    []string       // Hostnames rejected along with their subdomains, e.g. "localhost"
+	AllowedDomains  []string       // If set, only these domains and their subdomains are accepted
+	DeniedDomains   []string       // These domains and their subdomains are rejected
+}
+
+// NewDefaultURLPolicy returns a policy that only accepts http(s) URLs and rejects the DefaultBlockedNetworks and
+// DefaultBlockedHosts
+func NewDefaultURLPolicy() *URLPolicy {
+	return &URLPolicy{
+		AllowedSchemes:  slices.Clone(DefaultAllowedSchemes),
+		BlockedNetworks: slices.Clone(DefaultBlockedNetworks),
+		BlockedHosts:    slices.Clone(DefaultBlockedHosts),
+	}
+}
+
+// defaultURLPolicy is applied to the URLs in all job arguments during validation, see SetDefaultURLPolicy
+var defaultURLPolicy atomic.Pointer[URLPolicy]
+
+func init() {
+	defaultURLPolicy.Store(NewDefaultURLPolicy())
+}
+
+// DefaultURLPolicy returns the policy applied to the URLs in all job arguments during validation
+func DefaultURLPolicy() *URLPolicy {
+	return defaultURLPolicy.Load()
+}
+
+// SetDefaultURLPolicy atomically replaces the policy applied to the URLs in all job arguments and returns the previous
+// one. The policy is copied, so later changes to it have no effect. A nil policy restores NewDefaultURLPolicy; to
+// disable the checks, set an empty URLPolicy explicitly.
+func SetDefaultURLPolicy(policy *URLPolicy) *URLPolicy {
+	if policy == nil {
+		policy = NewDefaultURLPolicy()
+	} else {
+		policy = policy.clone()
+	}
+	return defaultURLPolicy.Swap(policy)
+}
+
+func (p *URLPolicy) clone() *URLPolicy {
+	return &URLPolicy{
+		AllowedSchemes:  slices.Clone(p.AllowedSchemes),
+		BlockedNetworks: slices.Clone(p.BlockedNetworks),
+		BlockedHosts:    slices.Clone(p.BlockedHosts),
+		AllowedDomains:  slices.Clone(p.AllowedDomains),
+		DeniedDomains:   slices.Clone(p.DeniedDomains),
+	}
+}
+
+// Check returns an error if the policy does not accept rawURL. A nil policy accepts all URLs.
+func (p *URLPolicy) Check(rawURL string) error {
+	if p == nil {
+		return nil
+	}
+
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return err
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	if len(p.AllowedSchemes) > 0 && !slices.Contains(p.AllowedSchemes, scheme) {
+		return fmt.Errorf("%w: %q (allowed: %s)", ErrURLSchemeNotAllowed, scheme, strings.Join(p.AllowedSchemes, ", "))
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return ErrURLHostMissing
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addr = addr.WithZone("").Unmap()
+		for _, network := range p.BlockedNetworks {
+			if network.Contains(addr) {
+				return fmt.Errorf("%w: %s is in %s", ErrURLAddressBlocked, addr, network)
+			}
+		}
+		return p.checkDomain(host)
+	}
+
+	if numericHostRe.MatchString(host) {
+		return fmt.Errorf("%w: %s is an ambiguous numeric address", ErrURLAddressBlocked, host)
+	}
+
+	if matchesDomain(host, p.BlockedHosts) {
+		return fmt.Errorf("%w: %s", ErrURLHostBlocked, host)
+	}
+
+	return p.checkDomain(host)
+}
+
+func (p *URLPolicy) checkDomain(host string) error {
+	if matchesDomain(host, p.DeniedDomains) {
+		return fmt.Errorf("%w: %s", ErrURLDomainDenied, host)
+	}
+	if len(p.AllowedDomains) > 0 && !matchesDomain(host, p.AllowedDomains) {
+		return fmt.Errorf("%w: %s", ErrURLDomainNotAllowed, host)
+	}
+	return nil
+}
+
+// matchesDomain returns true if host is one of the domains or a subdomain of one of them
+func matchesDomain(host string, domains []string) bool {
+	for _, domain := range domains {
+		domain = strings.TrimSuffix(strings.ToLower(domain), ".")
+		if host == domain || strings.HasSuffix(host, "."+domain) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkURLPolicy applies the DefaultURLPolicy to the URL in the given field
+func checkURLPolicy(field, rawURL string) error {
+	if err := DefaultURLPolicy().Check(rawURL); err != nil {
+		return NewValidationError(field, CodeNotAllowed, rawURL, err)
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package args_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("URLPolicy", func() {
+	Describe("default policy", func() {
+		policy := args.NewDefaultURLPolicy()
+
+		DescribeTable("accepted URLs",
+			func(rawURL string) {
+				Expect(policy.Check(rawURL)).To(Succeed())
+			},
+			Entry("https", "https://example.com/page"),
+			Entry("http with port", "http://example.com:8080/"),
+			Entry("public IPv4", "http://93.184.216.34/"),
+			Entry("public IPv6", "http://[2606:2800:220:1:248:1893:25c8:1946]/"),
+			Entry("hostname starting with a number", "https://1password.com/"),
+		)
+
+		DescribeTable("rejected URLs",
+			func(rawURL string, expected error) {
+				Expect(errors.Is(policy.Check(rawURL), expected)).To(BeTrue())
+			},
+			Entry("file", "file:///etc/passwd", args.ErrURLSchemeNotAllowed),
+			Entry("gopher", "gopher://example.com/", args.ErrURLSchemeNotAllowed),
+			Entry("no host", "http:///path", args.ErrURLHostMissing),
+			Entry("metadata endpoint", "http://169.254.169.254/latest/meta-data/", args.ErrURLAddressBlocked),
+			Entry("loopback", "http://127.0.0.1:8080/", args.ErrURLAddressBlocked),
+			Entry("private 10/8", "http://10.1.2.3/", args.ErrURLAddressBlocked),
+			Entry("private 172.16/12", "http://172.20.0.1/", args.ErrURLAddressBlocked),
+			Entry("private 192.168/16", "http://192.168.1.1/", args.ErrURLAddressBlocked),
+			Entry("unspecified", "http://0.0.0.0/", args.ErrURLAddressBlocked),
+			Entry("IPv6 loopback", "http://[::1]/", args.ErrURLAddressBlocked),
+			Entry("IPv6 unique local", "http://[fd00:ec2::254]/", args.ErrURLAddressBlocked),
+			Entry("IPv6 link-local with zone", "http://[fe80::1%25eth0]/", args.ErrURLAddressBlocked),
+			Entry("IPv4-mapped IPv6", "http://[::ffff:127.0.0.1]/", args.ErrURLAddressBlocked),
+			Entry("IPv4-compatible IPv6", "http://[::127.0.0.1]/", args.ErrURLAddressBlocked),
+			Entry("IPv6 unspecified", "http://[::]/", args.ErrURLAddressBlocked),
+			Entry("6to4", "http://[2002:7f00:1::]/", args.ErrURLAddressBlocked),
+			Entry("IPv4 documentation TEST-NET-1", "http://192.0.2.1/", args.ErrURLAddressBlocked),
+			Entry("IPv4 documentation TEST-NET-2", "http://198.51.100.1/", args.ErrURLAddressBlocked),
+			Entry("IPv4 documentation TEST-NET-3", "http://203.0.113.1/", args.ErrURLAddressBlocked),
+			Entry("IPv6 documentation", "http://[2001:db8::1]/", args.ErrURLAddressBlocked),
+			Entry("decimal IPv4", "http://2130706433/", args.ErrURLAddressBlocked),
+			Entry("short IPv4", "http://127.1/", args.ErrURLAddressBlocked),
+			Entry("hexadecimal IPv4", "http://0x7f.0x0.0x0.0x1/", args.ErrURLAddressBlocked),
+			Entry("localhost", "http://localhost:3000/", args.ErrURLHostBlocked),
+			Entry("localhost subdomain", "http://app.localhost/", args.ErrURLHostBlocked),
+			Entry("localhost with trailing dot", "http://LOCALHOST./", args.ErrURLHostBlocked),
+			Entry("GCP metadata", "http://metadata.google.internal/computeMetadata/v1/", args.ErrURLHostBlocked),
+		)
+	})
+
+	Describe("domain lists", func() {
+		It("should only accept the allowed domains and their subdomains", func() {
+			policy := args.NewDefaultURLPolicy()
+			policy.AllowedDomains = []string{"example.com"}
+			Expect(policy.Check("https://example.com/")).To(Succeed())
+			Expect(policy.Check("https://docs.example.com/")).To(Succeed())
+			Expect(errors.Is(policy.Check("https://notexample.com/"), args.ErrURLDomainNotAllowed)).To(BeTrue())
+		})
+
+		It("should reject the denied domains and their subdomains", func() {
+			policy := args.NewDefaultURLPolicy()
+			policy.DeniedDomains = []string{"internal.example.com"}
+			Expect(policy.Check("https://example.com/")).To(Succeed())
+			Expect(errors.Is(policy.Check("https://api.internal.example.com/"), args.ErrURLDomainDenied)).To(BeTrue())
+		})
+	})
+
+	It("should accept everything when nil", func() {
+		var policy *args.URLPolicy
+		Expect(policy.Check("file:///etc/passwd")).To(Succeed())
+	})
+
+	Describe("applied to job arguments", func() {
+		var saved *args.URLPolicy
+
+		BeforeEach(func() {
+			saved = args.DefaultURLPolicy()
+		})
+
+		AfterEach(func() {
+			args.SetDefaultURLPolicy(saved)
+		})
+
+		It("should reject blocked web start URLs", func() {
+			webArgs := &args.WebArguments{
+				QueryType: types.WebScraper,
+				URL:       "http://169.254.169.254/latest/meta-data/",
+				URLs:      []string{"https://example.com", "file:///etc/passwd"},
+				MaxPages:  1,
+			}
+			err := webArgs.Validate()
+			Expect(errors.Is(err, args.ErrURLAddressBlocked)).To(BeTrue())
+			Expect(errors.Is(err, args.ErrURLSchemeNotAllowed)).To(BeTrue())
+
+			validationErrs := args.ValidationErrors(err)
+			Expect(validationErrs).To(HaveLen(2))
+			Expect(validationErrs[0].Field).To(Equal("url"))
+			Expect(validationErrs[0].Code).To(Equal(args.CodeNotAllowed))
+			Expect(validationErrs[1].Field).To(Equal("urls[1]"))
+		})
+
+		It("should reject non-HTTP Reddit URLs", func() {
+			redditArgs := &args.RedditArguments{
+				QueryType: types.RedditScrapeUrls,
+				URLs:      []string{"gopher://reddit.com/r/golang/comments/abc123/title/"},
+				Sort:      types.RedditSortNew,
+			}
+			Expect(errors.Is(redditArgs.Validate(), args.ErrURLSchemeNotAllowed)).To(BeTrue())
+		})
+
+		It("should apply a custom policy", func() {
+			policy := args.NewDefaultURLPolicy()
+			policy.DeniedDomains = []string{"tiktok.com"}
+			args.SetDefaultURLPolicy(policy)
+			// The policy is copied when set
+			policy.DeniedDomains = []string{"linkedin.com"}
+
+			tiktokArgs := &args.TikTokTranscriptionArguments{VideoURL: "https://www.tiktok.com/@user/video/7234567890123456789"}
+			Expect(errors.Is(tiktokArgs.Validate(), args.ErrURLDomainDenied)).To(BeTrue())
+
+			linkedInArgs := &args.LinkedInArguments{QueryType: string(types.CapGetProfile), ProfileURL: "linkedin.com/in/john-doe"}
+			Expect(linkedInArgs.Validate()).To(Succeed())
+		})
+
+		It("should skip the checks with an empty policy", func() {
+			args.SetDefaultURLPolicy(&args.URLPolicy{})
+			webArgs := &args.WebArguments{QueryType: types.WebScraper, URL: "http://localhost:8080", MaxPages: 1}
+			Expect(webArgs.Validate()).To(Succeed())
+		})
+
+		It("should restore the default policy when set to nil", func() {
+			args.SetDefaultURLPolicy(&args.URLPolicy{})
+			previous := args.SetDefaultURLPolicy(nil)
+			Expect(previous).To(Equal(&args.URLPolicy{}))
+
+			webArgs := &args.WebArguments{QueryType: types.WebScraper, URL: "http://localhost:8080", MaxPages: 1}
+			Expect(errors.Is(webArgs.Validate(), args.ErrURLHostBlocked)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,84 @@
+package args
+
+import "github.com/masa-finance/tee-types/types"
+
+// ValidationCode is a machine-readable identifier for the kind of validation failure
+type ValidationCode string
+
+const (
+	CodeRequired      ValidationCode = "required"       // the field is missing or empty
+	CodeInvalidFormat ValidationCode = "invalid_format" // the field cannot be parsed
+	CodeInvalidValue  ValidationCode = "invalid_value"  // the field parses, but its value is not accepted
+	CodeOutOfRange    ValidationCode = "out_of_range"   // the numeric or time value is outside the accepted range
+	CodeNotAllowed    ValidationCode = "not_allowed"    // the field cannot be combined with the requested capability
+	CodeUnsupported   ValidationCode = "unsupported"    // the capability is not supported by the job type
+)
+
+// ValidationError describes a single problem with a single argument field.
+// Validate methods return one or more of these, combined with errors.Join.
+type ValidationError struct {
+	Field   string         `json:"field"`           // JSON path of the offending field, e.g. "urls[2]"
+	Code    ValidationCode `json:"code"`            // Machine-readable failure code
+	Value   any            `json:"value,omitempty"` // The offending value, if any
+	Message string         `json:"message"`         // Human-readable description
+	Err     error          `json:"-"`               // Underlying error, usually one of the package sentinels
+}
+
+// NewValidationError returns a ValidationError for field, taking the message from err
+func NewValidationError(field string, code ValidationCode, value any, err error) *ValidationError {
+	return &ValidationError{
+		Field:   field,
+		Code:    code,
+		Value:   value,
+		Message: err.Error(),
+		Err:     err,
+	}
+}
+
+// Error implements the error interface
+func (e *ValidationError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + ": " + e.Message
+}
+
+// Unwrap allows errors.Is and errors.As to match the underlying error
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors returns all the ValidationErrors contained in err, traversing wrapped and joined errors
+func ValidationErrors(err error) []*ValidationError {
+	var ret []*ValidationError
+
+	var walk func(error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		if ve, ok := err.(*ValidationError); ok {
+			ret = append(ret, ve)
+			return
+		}
+		switch e := err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, inner := range e.Unwrap() {
+				walk(inner)
+			}
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		}
+	}
+	walk(err)
+
+	return ret
+}
+
+// validateCapability checks that capability is valid for jobType, returning a ValidationError for the "type" field
+func validateCapability(jobType types.JobType, capability types.Capability) error {
+	if err := jobType.ValidateCapability(capability); err != nil {
+		return NewValidationError("type", CodeUnsupported, capability, err)
+	}
+	return nil
+}
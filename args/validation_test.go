@@ -0,0 +1,102 @@
+package args_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/args"
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("ValidationError", func() {
+	It("should report all the problems at once", func() {
+		webArgs := &args.WebArguments{MaxDepth: -1, MaxPages: 0}
+		err := webArgs.Validate()
+		Expect(err).To(HaveOccurred())
+
+		validationErrs := args.ValidationErrors(err)
+		Expect(validationErrs).To(HaveLen(3))
+		Expect(validationErrs[0].Field).To(Equal("url"))
+		Expect(validationErrs[0].Code).To(Equal(args.CodeRequired))
+		Expect(validationErrs[1].Field).To(Equal("max_depth"))
+		Expect(validationErrs[1].Code).To(Equal(args.CodeOutOfRange))
+		Expect(validationErrs[1].Value).To(Equal(-1))
+		Expect(validationErrs[2].Field).To(Equal("max_pages"))
+
+		Expect(errors.Is(err, args.ErrWebURLRequired)).To(BeTrue())
+		Expect(errors.Is(err, args.ErrWebMaxDepth)).To(BeTrue())
+		Expect(errors.Is(err, args.ErrWebMaxPages)).To(BeTrue())
+	})
+
+	It("should be usable with errors.As", func() {
+		twitterArgs := &args.TwitterSearchArguments{Count: -5}
+		err := twitterArgs.Validate()
+		var ve *args.ValidationError
+		Expect(errors.As(err, &ve)).To(BeTrue())
+		Expect(ve.Field).To(Equal("count"))
+		Expect(errors.Is(err, args.ErrTwitterCountNegative)).To(BeTrue())
+		Expect(err.Error()).To(Equal("count: must be non-negative, got: -5"))
+	})
+
+	It("should address individual URLs", func() {
+		redditArgs := &args.RedditArguments{
+			QueryType: types.RedditScrapeUrls,
+			URLs:      []string{"https://www.reddit.com/r/golang/comments/foo/bar", "https://example.com/r/golang/comments/foo"},
+			Sort:      types.RedditSortNew,
+		}
+		validationErrs := args.ValidationErrors(redditArgs.Validate())
+		Expect(validationErrs).To(HaveLen(1))
+		Expect(validationErrs[0].Field).To(Equal("urls[1]"))
+		Expect(validationErrs[0].Code).To(Equal(args.CodeInvalidValue))
+	})
+
+	It("should report unsupported capabilities on the type field", func() {
+		twitterArgs := &args.TwitterSearchArguments{QueryType: "nope"}
+		validationErrs := args.ValidationErrors(twitterArgs.ValidateForJobType(types.TwitterJob))
+		Expect(validationErrs).To(HaveLen(1))
+		Expect(validationErrs[0].Field).To(Equal("type"))
+		Expect(validationErrs[0].Code).To(Equal(args.CodeUnsupported))
+	})
+
+	It("should report unsupported capabilities along with the field errors", func() {
+		webArgs := &args.WebArguments{QueryType: types.WebScraper, URL: "https://example.com", MaxDepth: -1, MaxPages: 1}
+		validationErrs := args.ValidationErrors(webArgs.ValidateForJobType(types.TwitterJob))
+		fields := []string{}
+		for _, ve := range validationErrs {
+			fields = append(fields, ve.Field)
+		}
+		Expect(fields).To(ConsistOf("max_depth", "type"))
+
+		llmArgs := &args.LLMProcessorArguments{QueryType: "bogus"}
+		validationErrs = args.ValidationErrors(llmArgs.ValidateForJobType(types.LLMJob))
+		Expect(validationErrs).To(HaveLen(3))
+		Expect(validationErrs[2].Code).To(Equal(args.CodeUnsupported))
+	})
+
+	It("should survive wrapping by UnmarshalJobArguments", func() {
+		_, err := args.UnmarshalJobArguments(types.TiktokJob, map[string]any{
+			"type":         "searchbytrending",
+			"country_code": "XX",
+			"period":       "1",
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(errors.Is(err, args.ErrTikTokInvalidCountryCode)).To(BeTrue())
+		Expect(errors.Is(err, args.ErrTikTokInvalidPeriod)).To(BeTrue())
+
+		fields := []string{}
+		for _, ve := range args.ValidationErrors(err) {
+			fields = append(fields, ve.Field)
+		}
+		Expect(fields).To(ConsistOf("country_code", "period"))
+	})
+
+	It("should marshal to JSON", func() {
+		ve := args.NewValidationError("max_pages", args.CodeOutOfRange, 0, args.ErrWebMaxPages)
+		data, err := json.Marshal(ve)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(MatchJSON(`{"field":"max_pages","code":"out_of_range","value":0,"message":"must be at least 1"}`))
+	})
+})
@@ -1,34 +1,57 @@
 package args
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	teetypes "github.com/masa-finance/tee-types/types"
 )
 
 var (
-	ErrWebURLRequired      = errors.New("url is required")
+	ErrWebURLRequired      = errors.New("is required")
 	ErrWebURLInvalid       = errors.New("invalid URL format")
-	ErrWebURLSchemeMissing = errors.New("url must include a scheme (http:// or https://)")
-	ErrWebMaxDepth         = errors.New("max depth must be non-negative")
-	ErrWebMaxPages         = errors.New("max pages must be at least 1")
+	ErrWebURLSchemeMissing = errors.New("must include a scheme (http:// or https://)")
+	ErrWebMaxDepth         = errors.New("must be non-negative")
+	ErrWebMaxPages         = errors.New("must be at least 1")
+	ErrWebScopeInvalid     = errors.New("invalid crawl scope")
+	ErrWebRobotsInvalid    = errors.New("invalid robots policy")
+
+	ErrWebMaxDepthNotAllowed      = errors.New("not allowed for sitemap crawls, which do not follow links")
+	ErrWebModifiedSinceNotAllowed = errors.New("only allowed for sitemap crawls")
+	ErrWebModifiedSinceInFuture   = errors.New("is in the future")
+	ErrWebSitemapNoPages          = errors.New("the sitemaps do not list any page to crawl")
 )
 
 const (
-	WebDefaultMaxPages             = 1
-	WebDefaultMethod               = "GET"
-	WebDefaultRespectRobotsTxtFile = false
-	WebDefaultSaveMarkdown         = true
+	WebDefaultMaxPages        = 1
+	WebDefaultSitemapMaxPages = 100
+	WebDefaultMethod          = "GET"
+	WebDefaultSaveMarkdown    = true
+	WebDefaultScope           = teetypes.WebScopeSameHost
+	WebDefaultRobotsPolicy    = teetypes.WebRobotsIgnore
+
+	// Deprecated: use WebDefaultRobotsPolicy
+	WebDefaultRespectRobotsTxtFile = WebDefaultRobotsPolicy == teetypes.WebRobotsRespect
 )
 
 type WebArguments struct {
-	QueryType teetypes.WebQueryType `json:"type"`
-	URL       string                `json:"url"`
-	MaxDepth  int                   `json:"max_depth"`
-	MaxPages  int                   `json:"max_pages"`
+	QueryType       teetypes.WebQueryType    `json:"type"`
+	URL             string                   `json:"url"`
+	URLs            []string                 `json:"urls,omitempty"` // Additional start URLs
+	MaxDepth        int                      `json:"max_depth"`
+	MaxPages        int                      `json:"max_pages"`
+	Scope           teetypes.WebCrawlScope   `json:"scope,omitempty"`            // Which discovered URLs may be followed, default same_host
+	IncludePatterns []teetypes.WebURLPattern `json:"include_patterns,omitempty"` // If set, only matching URLs are followed
+	ExcludePatterns []teetypes.WebURLPattern `json:"exclude_patterns,omitempty"` // Matching URLs are never followed
+	Robots          teetypes.WebRobotsPolicy `json:"robots,omitempty"`           // Whether to respect robots.txt, default ignore
+	ModifiedSince   time.Time                `json:"modified_since,omitzero"`    // Sitemap crawls skip pages last modified before this time
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling with validation
@@ -51,62 +74,225 @@ func (w *WebArguments) UnmarshalJSON(data []byte) error {
 }
 
 func (w *WebArguments) setDefaultValues() {
+	w.QueryType = teetypes.WebQueryType(strings.ToLower(string(w.QueryType)))
 	if w.MaxPages == 0 {
 		w.MaxPages = WebDefaultMaxPages
+		if w.IsSitemapOperation() {
+			w.MaxPages = WebDefaultSitemapMaxPages
+		}
+	}
+	if w.Scope == "" {
+		w.Scope = WebDefaultScope
+	}
+	if w.Robots == "" {
+		w.Robots = WebDefaultRobotsPolicy
 	}
+	w.Scope = teetypes.WebCrawlScope(strings.ToLower(string(w.Scope)))
+	w.Robots = teetypes.WebRobotsPolicy(strings.ToLower(string(w.Robots)))
 }
 
 // Validate validates the Web arguments
 func (w *WebArguments) Validate() error {
-	if w.URL == "" {
-		return ErrWebURLRequired
-	}
+	var errs []error
 
-	// Validate URL format
-	parsedURL, err := url.Parse(w.URL)
-	if err != nil {
-		return fmt.Errorf("%w: %v", ErrWebURLInvalid, err)
+	if w.URL == "" && len(w.URLs) == 0 {
+		errs = append(errs, NewValidationError("url", CodeRequired, nil, ErrWebURLRequired))
+	} else if w.URL != "" {
+		errs = appendWebURLError(errs, "url", w.URL)
 	}
-
-	// Ensure URL has a scheme
-	if parsedURL.Scheme == "" {
-		return ErrWebURLSchemeMissing
+	for i, u := range w.URLs {
+		errs = appendWebURLError(errs, fmt.Sprintf("urls[%d]", i), u)
 	}
 
 	if w.MaxDepth < 0 {
-		return fmt.Errorf("%w: got %v", ErrWebMaxDepth, w.MaxDepth)
+		errs = append(errs, NewValidationError("max_depth", CodeOutOfRange, w.MaxDepth, fmt.Errorf("%w: got %v", ErrWebMaxDepth, w.MaxDepth)))
+	}
+
+	if w.IsSitemapOperation() {
+		if w.MaxDepth > 0 {
+			errs = append(errs, NewValidationError("max_depth", CodeNotAllowed, w.MaxDepth, ErrWebMaxDepthNotAllowed))
+		}
+		if time.Now().Before(w.ModifiedSince) {
+			errs = append(errs, NewValidationError("modified_since", CodeOutOfRange, w.ModifiedSince, ErrWebModifiedSinceInFuture))
+		}
+	} else if !w.ModifiedSince.IsZero() {
+		errs = append(errs, NewValidationError("modified_since", CodeNotAllowed, w.ModifiedSince, ErrWebModifiedSinceNotAllowed))
 	}
 
 	if w.MaxPages < 1 {
-		return fmt.Errorf("%w: got %v", ErrWebMaxPages, w.MaxPages)
+		errs = append(errs, NewValidationError("max_pages", CodeOutOfRange, w.MaxPages, fmt.Errorf("%w: got %v", ErrWebMaxPages, w.MaxPages)))
+	}
+
+	if w.Scope != "" && !teetypes.AllWebCrawlScopes.Contains(w.Scope) {
+		errs = append(errs, NewValidationError("scope", CodeInvalidValue, w.Scope, fmt.Errorf("%w: %s", ErrWebScopeInvalid, w.Scope)))
 	}
 
-	return nil
+	if w.Robots != "" && !teetypes.AllWebRobotsPolicies.Contains(w.Robots) {
+		errs = append(errs, NewValidationError("robots", CodeInvalidValue, w.Robots, fmt.Errorf("%w: %s", ErrWebRobotsInvalid, w.Robots)))
+	}
+
+	errs = appendWebPatternErrors(errs, "include_patterns", w.IncludePatterns)
+	errs = appendWebPatternErrors(errs, "exclude_patterns", w.ExcludePatterns)
+
+	return errors.Join(errs...)
 }
 
-// ValidateForJobType validates Web arguments for a specific job type
-func (w *WebArguments) ValidateForJobType(jobType teetypes.JobType) error {
-	if err := w.Validate(); err != nil {
-		return err
+func appendWebURLError(errs []error, field string, rawURL string) []error {
+	if parsedURL, err := url.Parse(rawURL); err != nil {
+		// Validate URL format
+		errs = append(errs, NewValidationError(field, CodeInvalidFormat, rawURL, fmt.Errorf("%w: %v", ErrWebURLInvalid, err)))
+	} else if parsedURL.Scheme == "" {
+		// Ensure URL has a scheme
+		errs = append(errs, NewValidationError(field, CodeInvalidFormat, rawURL, ErrWebURLSchemeMissing))
+	} else if err := checkURLPolicy(field, rawURL); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func appendWebPatternErrors(errs []error, field string, patterns []teetypes.WebURLPattern) []error {
+	for i, p := range patterns {
+		if _, err := p.Compile(); err != nil {
+			errs = append(errs, NewValidationError(fmt.Sprintf("%s[%d]", field, i), CodeInvalidFormat, p.String(), err))
+		}
+	}
+	return errs
+}
+
+// GetStartURLs returns URL followed by URLs, without duplicates
+func (w *WebArguments) GetStartURLs() []string {
+	var ret []string
+	for _, u := range append([]string{w.URL}, w.URLs...) {
+		if u != "" && !slices.Contains(ret, u) {
+			ret = append(ret, u)
+		}
 	}
+	return ret
+}
 
-	// Validate capability against job-specific capabilities
-	return jobType.ValidateCapability(w.GetCapability())
+// ValidateForJobType validates Web arguments for a specific job type
+func (w *WebArguments) ValidateForJobType(jobType teetypes.JobType) error {
+	// Validate capability against job-specific capabilities, reporting it along with any field errors
+	return errors.Join(w.Validate(), validateCapability(jobType, w.GetCapability()))
 }
 
-// GetCapability returns the capability for web operations (always scraper)
+// GetCapability returns the capability for web operations: sitemap for sitemap crawls, scraper otherwise
 func (w *WebArguments) GetCapability() teetypes.Capability {
+	if w.IsSitemapOperation() {
+		return teetypes.CapSitemap
+	}
 	return teetypes.CapScraper
 }
 
+// IsSitemapOperation returns true if the start URLs are sitemaps whose pages should be fetched
+func (w *WebArguments) IsSitemapOperation() bool {
+	return w.QueryType == teetypes.WebSitemap
+}
+
 func (w WebArguments) ToWebScraperRequest() teetypes.WebScraperRequest {
-	return teetypes.WebScraperRequest{
-		StartUrls: []teetypes.WebStartURL{
-			{URL: w.URL, Method: WebDefaultMethod},
-		},
+	startURLs := w.GetStartURLs()
+	req := teetypes.WebScraperRequest{
+		StartUrls:            make([]teetypes.WebStartURL, len(startURLs)),
 		MaxCrawlDepth:        w.MaxDepth,
 		MaxCrawlPages:        w.MaxPages,
-		RespectRobotsTxtFile: WebDefaultRespectRobotsTxtFile,
+		RespectRobotsTxtFile: w.Robots == teetypes.WebRobotsRespect,
 		SaveMarkdown:         WebDefaultSaveMarkdown,
+		CrawlScope:           w.Scope,
+	}
+	req.SetURLPatterns(w.IncludePatterns, w.ExcludePatterns)
+	for i, u := range startURLs {
+		req.StartUrls[i] = teetypes.WebStartURL{URL: u, Method: WebDefaultMethod}
+	}
+	if req.CrawlScope == "" {
+		req.CrawlScope = WebDefaultScope
+	}
+	return req
+}
+
+// SitemapExpandOptions returns the options to expand the sitemaps of a sitemap crawl. Pages must be in the crawl scope
+// of a sitemap, where the path prefix is the directory of the sitemap as in the sitemap protocol, match the URL
+// patterns and pass the DefaultURLPolicy. Sitemaps only need to pass the DefaultURLPolicy.
+func (w *WebArguments) SitemapExpandOptions() (teetypes.SitemapExpandOptions, error) {
+	var scopeURLs []string
+	for _, sitemapURL := range w.GetStartURLs() {
+		u, err := url.Parse(sitemapURL)
+		if err != nil {
+			return teetypes.SitemapExpandOptions{}, err
+		}
+		scopeURLs = append(scopeURLs, u.ResolveReference(&url.URL{Path: "./"}).String())
+	}
+
+	filter, err := teetypes.NewWebURLFilter(scopeURLs, w.Scope, w.IncludePatterns, w.ExcludePatterns)
+	if err != nil {
+		return teetypes.SitemapExpandOptions{}, err
+	}
+
+	policy := DefaultURLPolicy()
+	return teetypes.SitemapExpandOptions{
+		ModifiedSince: w.ModifiedSince,
+		AllowPage: func(pageURL string) bool {
+			return filter.Allows(pageURL) && policy.Check(pageURL) == nil
+		},
+		AllowSitemap: func(sitemapURL string) bool {
+			return policy.Check(sitemapURL) == nil
+		},
+		MaxURLs: w.MaxPages,
+	}, nil
+}
+
+// ToSitemapScraperRequest expands the sitemaps of a sitemap crawl, fetched with fetch, and returns the request that
+// fetches the pages they list without following links. It returns ErrWebSitemapNoPages if no page is left to crawl.
+func (w WebArguments) ToSitemapScraperRequest(ctx context.Context, fetch teetypes.SitemapFetcher) (teetypes.WebScraperRequest, error) {
+	opts, err := w.SitemapExpandOptions()
+	if err != nil {
+		return teetypes.WebScraperRequest{}, err
+	}
+
+	// The start URLs were checked during validation, but the sitemap indexes may point anywhere
+	policy := DefaultURLPolicy()
+	guarded := func(ctx context.Context, sitemapURL string) (io.ReadCloser, error) {
+		if err := policy.Check(sitemapURL); err != nil {
+			return nil, err
+		}
+		return fetch(ctx, sitemapURL)
+	}
+
+	// Sitemaps listed in indexes that fail are skipped, and only reported if no page is left
+	var skipped []error
+	opts.OnSitemapError = func(_ string, err error) {
+		skipped = append(skipped, err)
+	}
+
+	pages, err := teetypes.ExpandSitemaps(ctx, guarded, w.GetStartURLs(), opts)
+	if err != nil {
+		return teetypes.WebScraperRequest{}, err
+	}
+	if len(pages) == 0 {
+		// All the pages may have been filtered out, e.g. by modified_since on an incremental re-crawl
+		return teetypes.WebScraperRequest{}, errors.Join(append([]error{ErrWebSitemapNoPages}, skipped...)...)
+	}
+	return w.ToWebScraperRequest().WithSitemapURLs(pages), nil
+}
+
+// AnnotateSchema adds the Web validation rules and defaults to the generated JSON Schema
+func (w *WebArguments) AnnotateSchema(capability teetypes.Capability, schema *JSONSchema) {
+	nonEmpty := func(name string, s *JSONSchema) *JSONSchema {
+		return (&JSONSchema{Properties: map[string]*JSONSchema{name: s}}).SetRequired(name)
+	}
+	schema.AnyOf = []*JSONSchema{
+		nonEmpty("url", &JSONSchema{Type: "string", Format: "uri"}),
+		nonEmpty("urls", (&JSONSchema{}).SetMinItems(1)),
+	}
+	schema.Property("url").Format = "uri"
+	schema.Property("urls").Items.Format = "uri"
+	schema.Property("robots").SetEnum(enumFromSet(teetypes.AllWebRobotsPolicies)...).SetDefault(WebDefaultRobotsPolicy)
+	schema.Property("scope").SetEnum(enumFromSet(teetypes.AllWebCrawlScopes)...).SetDefault(WebDefaultScope)
+	schema.Property("max_depth").SetMinimum(0)
+	if capability == teetypes.CapSitemap {
+		schema.Property("url").Description = "Sitemap or sitemap index URL"
+		schema.Property("max_depth").SetMaximum(0)
+		schema.Property("max_pages").SetMinimum(1).SetDefault(WebDefaultSitemapMaxPages)
+	} else {
+		schema.Property("max_pages").SetMinimum(1).SetDefault(WebDefaultMaxPages)
 	}
 }
@@ -1,8 +1,12 @@
 package args_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -47,6 +51,45 @@ var _ = Describe("WebArguments", func() {
 			err := json.Unmarshal(jsonData, &webArgs)
 			Expect(errors.Is(err, args.ErrWebURLRequired)).To(BeTrue())
 		})
+
+		It("should unmarshal start URLs, scope and patterns", func() {
+			var webArgs args.WebArguments
+			jsonData := []byte(`{
+				"type": "scraper",
+				"urls": ["https://example.com/docs", "https://example.com/blog"],
+				"scope": "PATH_PREFIX",
+				"include_patterns": ["https://example.com/docs/**", {"regex": "/blog/[0-9]{4}/"}],
+				"exclude_patterns": [{"glob": "**/*.pdf"}]
+			}`)
+			Expect(json.Unmarshal(jsonData, &webArgs)).To(Succeed())
+			Expect(webArgs.GetStartURLs()).To(Equal([]string{"https://example.com/docs", "https://example.com/blog"}))
+			Expect(webArgs.Scope).To(Equal(types.WebScopePathPrefix))
+			Expect(webArgs.IncludePatterns).To(Equal([]types.WebURLPattern{
+				{Glob: "https://example.com/docs/**"},
+				{Regex: "/blog/[0-9]{4}/"},
+			}))
+			Expect(webArgs.ExcludePatterns).To(Equal([]types.WebURLPattern{{Glob: "**/*.pdf"}}))
+		})
+
+		It("should default the scope to the same host", func() {
+			var webArgs args.WebArguments
+			Expect(json.Unmarshal([]byte(`{"type":"scraper","url":"https://example.com"}`), &webArgs)).To(Succeed())
+			Expect(webArgs.Scope).To(Equal(types.WebScopeSameHost))
+		})
+
+		It("should unmarshal the robots policy", func() {
+			var webArgs args.WebArguments
+			Expect(json.Unmarshal([]byte(`{"type":"scraper","url":"https://example.com","robots":"Respect"}`), &webArgs)).To(Succeed())
+			Expect(webArgs.Robots).To(Equal(types.WebRobotsRespect))
+			Expect(webArgs.ToWebScraperRequest().RespectRobotsTxtFile).To(BeTrue())
+		})
+
+		It("should default the robots policy to ignore", func() {
+			var webArgs args.WebArguments
+			Expect(json.Unmarshal([]byte(`{"type":"scraper","url":"https://example.com"}`), &webArgs)).To(Succeed())
+			Expect(webArgs.Robots).To(Equal(types.WebRobotsIgnore))
+			Expect(webArgs.ToWebScraperRequest().RespectRobotsTxtFile).To(BeFalse())
+		})
 	})
 
 	Describe("Validation", func() {
@@ -117,6 +160,69 @@ var _ = Describe("WebArguments", func() {
 			Expect(errors.Is(err, args.ErrWebMaxPages)).To(BeTrue())
 			Expect(err.Error()).To(ContainSubstring("got 0"))
 		})
+
+		It("should accept start URLs without url", func() {
+			webArgs := &args.WebArguments{
+				QueryType: types.WebScraper,
+				URLs:      []string{"https://example.com", "https://example.org"},
+				MaxPages:  1,
+			}
+			Expect(webArgs.Validate()).To(Succeed())
+		})
+
+		It("should report each invalid start URL", func() {
+			webArgs := &args.WebArguments{
+				QueryType: types.WebScraper,
+				URLs:      []string{"https://example.com", "example.org", "http:// invalid.com"},
+				MaxPages:  1,
+			}
+			err := webArgs.Validate()
+			Expect(errors.Is(err, args.ErrWebURLSchemeMissing)).To(BeTrue())
+			Expect(errors.Is(err, args.ErrWebURLInvalid)).To(BeTrue())
+
+			validationErrs := args.ValidationErrors(err)
+			Expect(validationErrs).To(HaveLen(2))
+			Expect(validationErrs[0].Field).To(Equal("urls[1]"))
+			Expect(validationErrs[1].Field).To(Equal("urls[2]"))
+		})
+
+		It("should fail with an invalid scope", func() {
+			webArgs := &args.WebArguments{
+				QueryType: types.WebScraper,
+				URL:       "https://example.com",
+				MaxPages:  1,
+				Scope:     "anywhere",
+			}
+			Expect(errors.Is(webArgs.Validate(), args.ErrWebScopeInvalid)).To(BeTrue())
+		})
+
+		It("should fail with an invalid robots policy", func() {
+			webArgs := &args.WebArguments{
+				QueryType: types.WebScraper,
+				URL:       "https://example.com",
+				MaxPages:  1,
+				Robots:    "sometimes",
+			}
+			Expect(errors.Is(webArgs.Validate(), args.ErrWebRobotsInvalid)).To(BeTrue())
+		})
+
+		It("should fail with invalid patterns", func() {
+			webArgs := &args.WebArguments{
+				QueryType:       types.WebScraper,
+				URL:             "https://example.com",
+				MaxPages:        1,
+				IncludePatterns: []types.WebURLPattern{{Glob: "https://example.com/{a,b"}},
+				ExcludePatterns: []types.WebURLPattern{{Regex: "("}, {}},
+			}
+			err := webArgs.Validate()
+			Expect(errors.Is(err, types.ErrWebURLPatternInvalid)).To(BeTrue())
+
+			validationErrs := args.ValidationErrors(err)
+			Expect(validationErrs).To(HaveLen(3))
+			Expect(validationErrs[0].Field).To(Equal("include_patterns[0]"))
+			Expect(validationErrs[1].Field).To(Equal("exclude_patterns[0]"))
+			Expect(validationErrs[2].Field).To(Equal("exclude_patterns[1]"))
+		})
 	})
 
 	Describe("Job capability", func() {
@@ -153,6 +259,157 @@ var _ = Describe("WebArguments", func() {
 			Expect(req.MaxCrawlPages).To(Equal(3))
 			Expect(req.RespectRobotsTxtFile).To(BeFalse())
 			Expect(req.SaveMarkdown).To(BeTrue())
+			Expect(req.CrawlScope).To(Equal(types.WebScopeSameHost))
+		})
+
+		It("should map all the start URLs, the scope and the patterns", func() {
+			webArgs := args.WebArguments{
+				QueryType:       types.WebScraper,
+				URL:             "https://example.com/docs",
+				URLs:            []string{"https://example.com/docs", "https://example.com/api"},
+				MaxPages:        10,
+				Scope:           types.WebScopePathPrefix,
+				IncludePatterns: []types.WebURLPattern{{Glob: "**/v2/**"}},
+				ExcludePatterns: []types.WebURLPattern{{Regex: `\.pdf$`}},
+			}
+			req := webArgs.ToWebScraperRequest()
+			Expect(req.StartUrls).To(Equal([]types.WebStartURL{
+				{URL: "https://example.com/docs", Method: "GET"},
+				{URL: "https://example.com/api", Method: "GET"},
+			}))
+			Expect(req.CrawlScope).To(Equal(types.WebScopePathPrefix))
+			Expect(req.IncludeUrlPatterns).To(Equal(webArgs.IncludePatterns))
+			Expect(req.ExcludeUrlPatterns).To(Equal(webArgs.ExcludePatterns))
+			Expect(req.IncludeUrlGlobs).To(Equal([]types.WebURLGlob{{Glob: "**/v2/**"}}))
+			Expect(req.ExcludeUrlGlobs).To(BeEmpty())
+		})
+
+		It("should only send the actor inputs", func() {
+			webArgs := args.WebArguments{
+				QueryType:       types.WebScraper,
+				URL:             "https://example.com/docs",
+				Scope:           types.WebScopeSameDomain,
+				ExcludePatterns: []types.WebURLPattern{{Glob: "https://example.com/private/**"}, {Regex: `\.pdf$`}},
+			}
+			data, err := json.Marshal(webArgs.ToWebScraperRequest())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"excludeUrlGlobs":[{"glob":"https://example.com/private/**"}]`))
+			Expect(string(data)).ToNot(ContainSubstring("includeUrlGlobs"))
+			Expect(string(data)).ToNot(ContainSubstring("Scope"))
+			Expect(string(data)).ToNot(ContainSubstring("Patterns"))
+		})
+
+		It("should not send include globs when an include pattern is a regex", func() {
+			webArgs := args.WebArguments{
+				QueryType:       types.WebScraper,
+				URL:             "https://example.com",
+				IncludePatterns: []types.WebURLPattern{{Glob: "https://example.com/docs/**"}, {Regex: "/blog/"}},
+			}
+			req := webArgs.ToWebScraperRequest()
+			Expect(req.IncludeUrlGlobs).To(BeNil())
+			Expect(req.IncludeUrlPatterns).To(HaveLen(2))
+		})
+	})
+
+	Describe("Sitemap crawls", func() {
+		It("should unmarshal sitemap arguments with defaults", func() {
+			var webArgs args.WebArguments
+			jsonData := []byte(`{"type":"Sitemap","url":"https://example.com/sitemap.xml","modified_since":"2024-04-01T00:00:00Z"}`)
+			Expect(json.Unmarshal(jsonData, &webArgs)).To(Succeed())
+			Expect(webArgs.IsSitemapOperation()).To(BeTrue())
+			Expect(webArgs.GetCapability()).To(Equal(types.CapSitemap))
+			Expect(webArgs.MaxPages).To(Equal(args.WebDefaultSitemapMaxPages))
+			Expect(webArgs.ModifiedSince).To(Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(webArgs.ValidateForJobType(types.WebJob)).To(Succeed())
+		})
+
+		It("should not allow a max depth", func() {
+			webArgs := &args.WebArguments{QueryType: types.WebSitemap, URL: "https://example.com/sitemap.xml", MaxDepth: 1, MaxPages: 10}
+			err := webArgs.Validate()
+			Expect(errors.Is(err, args.ErrWebMaxDepthNotAllowed)).To(BeTrue())
+		})
+
+		It("should not allow a cutoff in the future", func() {
+			webArgs := &args.WebArguments{QueryType: types.WebSitemap, URL: "https://example.com/sitemap.xml", MaxPages: 10, ModifiedSince: time.Now().Add(time.Hour)}
+			Expect(errors.Is(webArgs.Validate(), args.ErrWebModifiedSinceInFuture)).To(BeTrue())
+		})
+
+		It("should only allow a cutoff for sitemap crawls", func() {
+			webArgs := &args.WebArguments{QueryType: types.WebScraper, URL: "https://example.com", MaxPages: 1, ModifiedSince: time.Now().Add(-time.Hour)}
+			Expect(errors.Is(webArgs.Validate(), args.ErrWebModifiedSinceNotAllowed)).To(BeTrue())
+		})
+
+		It("should expand the sitemaps into the start URLs", func() {
+			docs := map[string]string{
+				"https://example.com/docs/sitemap.xml": `<sitemapindex>
+					<sitemap><loc>https://example.com/docs/pages.xml</loc></sitemap>
+					<sitemap><loc>http://169.254.169.254/latest/meta-data/</loc></sitemap>
+				</sitemapindex>`,
+				"https://example.com/docs/pages.xml": `<urlset>
+					<url><loc>https://example.com/docs/intro</loc><lastmod>2024-05-01</lastmod></url>
+					<url><loc>https://example.com/docs/old</loc><lastmod>2023-01-01</lastmod></url>
+					<url><loc>https://example.com/docs/manual.pdf</loc></url>
+					<url><loc>https://example.com/blog/post</loc></url>
+					<url><loc>https://other.example.org/docs/page</loc></url>
+				</urlset>`,
+			}
+			var fetched []string
+			fetch := func(_ context.Context, sitemapURL string) (io.ReadCloser, error) {
+				fetched = append(fetched, sitemapURL)
+				return io.NopCloser(bytes.NewReader([]byte(docs[sitemapURL]))), nil
+			}
+
+			webArgs := args.WebArguments{
+				QueryType:       types.WebSitemap,
+				URL:             "https://example.com/docs/sitemap.xml",
+				MaxPages:        10,
+				Scope:           types.WebScopePathPrefix,
+				ExcludePatterns: []types.WebURLPattern{{Glob: "**/*.pdf"}},
+				ModifiedSince:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			}
+			req, err := webArgs.ToSitemapScraperRequest(context.Background(), fetch)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(req.StartUrls).To(Equal([]types.WebStartURL{{URL: "https://example.com/docs/intro", Method: "GET"}}))
+			Expect(req.MaxCrawlDepth).To(BeZero())
+			Expect(req.MaxCrawlPages).To(Equal(1))
+			Expect(fetched).To(Equal([]string{"https://example.com/docs/sitemap.xml", "https://example.com/docs/pages.xml"}))
+		})
+
+		It("should fail when no page is left to crawl", func() {
+			fetch := func(_ context.Context, _ string) (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader([]byte(`<urlset>
+					<url><loc>https://example.com/old</loc><lastmod>2023-01-01</lastmod></url>
+				</urlset>`))), nil
+			}
+			webArgs := args.WebArguments{
+				QueryType:     types.WebSitemap,
+				URL:           "https://example.com/sitemap.xml",
+				MaxPages:      10,
+				ModifiedSince: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			}
+			_, err := webArgs.ToSitemapScraperRequest(context.Background(), fetch)
+			Expect(errors.Is(err, args.ErrWebSitemapNoPages)).To(BeTrue())
+		})
+
+		It("should report the skipped sitemaps when no page is left to crawl", func() {
+			fetch := func(_ context.Context, sitemapURL string) (io.ReadCloser, error) {
+				if sitemapURL == "https://example.com/sitemap.xml" {
+					return io.NopCloser(bytes.NewReader([]byte(`<sitemapindex>
+						<sitemap><loc>https://example.com/missing.xml</loc></sitemap>
+					</sitemapindex>`))), nil
+				}
+				return nil, errors.New("404 not found")
+			}
+			webArgs := args.WebArguments{QueryType: types.WebSitemap, URL: "https://example.com/sitemap.xml", MaxPages: 10}
+			_, err := webArgs.ToSitemapScraperRequest(context.Background(), fetch)
+			Expect(errors.Is(err, args.ErrWebSitemapNoPages)).To(BeTrue())
+			Expect(err).To(MatchError(ContainSubstring("https://example.com/missing.xml")))
+		})
+
+		It("should not marshal an unset cutoff", func() {
+			data, err := json.Marshal(args.WebArguments{QueryType: types.WebScraper, URL: "https://example.com"})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(data)).ToNot(ContainSubstring("modified_since"))
 		})
 	})
 })
@@ -19,7 +19,7 @@ require (
 require (
 	github.com/google/go-cmp v0.7.0 // indirect
 	github.com/onsi/ginkgo/v2 v2.23.4
-	golang.org/x/net v0.42.0 // indirect
+	golang.org/x/net v0.42.0
 	golang.org/x/text v0.27.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,314 @@
+package types
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Platform identifies the source platform of normalized documents and profiles
+type Platform string
+
+const (
+	PlatformTwitter  Platform = "twitter"
+	PlatformReddit   Platform = "reddit"
+	PlatformTikTok   Platform = "tiktok"
+	PlatformWeb      Platform = "web"
+	PlatformLinkedIn Platform = "linkedin"
+)
+
+type DocumentKind string
+
+const (
+	DocumentPost       DocumentKind = "post"
+	DocumentComment    DocumentKind = "comment"
+	DocumentVideo      DocumentKind = "video"
+	DocumentTranscript DocumentKind = "transcript"
+	DocumentPage       DocumentKind = "page"
+)
+
+type MediaType string
+
+const (
+	MediaImage MediaType = "image"
+	MediaVideo MediaType = "video"
+)
+
+// Document is the platform-agnostic representation of a piece of scraped content, used for indexing, search,
+// deduplication and LLM processing across platforms
+type Document struct {
+	ID         string             `json:"id"` // Platform-native ID (prefixed for derived kinds, e.g. transcripts), see Key for a globally unique one
+	Platform   Platform           `json:"platform"`
+	Kind       DocumentKind       `json:"kind"`
+	URL        string             `json:"url,omitempty"`
+	Author     DocumentAuthor     `json:"author"`
+	Title      string             `json:"title,omitempty"`
+	Text       string             `json:"text"`
+	CreatedAt  time.Time          `json:"created_at"`
+	ScrapedAt  time.Time          `json:"scraped_at"`
+	Language   string             `json:"language,omitempty"`
+	Engagement DocumentEngagement `json:"engagement"`
+	Media      []DocumentMedia    `json:"media,omitempty"`
+	Hashtags   []string           `json:"hashtags,omitempty"`
+	Community  string             `json:"community,omitempty"` // e.g. the subreddit
+	ParentID   string             `json:"parent_id,omitempty"` // ID of the document this one replies to
+	ThreadID   string             `json:"thread_id,omitempty"` // ID of the root document of the conversation
+}
+
+// DocumentAuthor identifies the author of a Document
+type DocumentAuthor struct {
+	ID          string `json:"id,omitempty"`
+	Username    string `json:"username,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+}
+
+// DocumentEngagement holds the engagement counters of a Document. Counters not reported by the platform are zero.
+type DocumentEngagement struct {
+	Likes     int64 `json:"likes"`     // likes, upvotes, diggs
+	Replies   int64 `json:"replies"`   // replies, comments
+	Shares    int64 `json:"shares"`    // retweets, shares
+	Quotes    int64 `json:"quotes"`    // quote tweets
+	Views     int64 `json:"views"`     // views, impressions, plays
+	Bookmarks int64 `json:"bookmarks"` // bookmarks
+}
+
+// DocumentMedia is a media item attached to a Document
+type DocumentMedia struct {
+	Type       MediaType `json:"type"`
+	URL        string    `json:"url,omitempty"`
+	PreviewURL string    `json:"preview_url,omitempty"`
+	Duration   int       `json:"duration,omitempty"` // in seconds, for videos
+}
+
+// Key returns an identifier that is unique across platforms, suitable for deduplication
+func (d Document) Key() string {
+	return string(d.Platform) + ":" + d.ID
+}
+
+// ToDocument converts a tweet to a normalized Document
+func (t *TweetResult) ToDocument() Document {
+	id := t.TweetID
+	if id == "" && t.ID != 0 {
+		id = strconv.FormatInt(t.ID, 10)
+	}
+
+	authorID := t.UserID
+	if authorID == "" {
+		authorID = t.AuthorID
+	}
+
+	createdAt := t.CreatedAt
+	if createdAt.IsZero() && t.Timestamp != 0 {
+		createdAt = time.Unix(t.Timestamp, 0)
+	}
+
+	doc := Document{
+		ID:       id,
+		Platform: PlatformTwitter,
+		Kind:     DocumentPost,
+		Author:   DocumentAuthor{ID: authorID, Username: t.Username},
+		Text:     t.Text,
+		Language: t.Lang,
+		Engagement: DocumentEngagement{
+			Likes:     int64(max(t.Likes, t.PublicMetrics.LikeCount)),
+			Replies:   int64(max(t.Replies, t.PublicMetrics.ReplyCount)),
+			Shares:    int64(max(t.Retweets, t.PublicMetrics.RetweetCount)),
+			Quotes:    int64(t.PublicMetrics.QuoteCount),
+			Views:     int64(max(t.Views, t.PublicMetrics.ImpressionCount)),
+			Bookmarks: int64(t.PublicMetrics.BookmarkCount),
+		},
+		Hashtags:  t.Hashtags,
+		CreatedAt: createdAt.UTC(),
+	}
+
+	if t.Username != "" && id != "" {
+		doc.URL = fmt.Sprintf("https://x.com/%s/status/%s", t.Username, id)
+	}
+
+	if t.ConversationID != "" {
+		doc.ThreadID = t.ConversationID
+		if t.IsReply && t.ConversationID != id {
+			doc.Kind = DocumentComment
+		}
+	}
+
+	for _, photo := range t.Photos {
+		doc.Media = append(doc.Media, DocumentMedia{Type: MediaImage, URL: photo.URL})
+	}
+	for _, video := range t.Videos {
+		doc.Media = append(doc.Media, DocumentMedia{Type: MediaVideo, URL: video.URL, PreviewURL: video.Preview})
+	}
+
+	return doc
+}
+
+// ToDocument converts a Reddit post to a normalized Document
+func (p *RedditPost) ToDocument() Document {
+	return Document{
+		ID:        p.ID,
+		Platform:  PlatformReddit,
+		Kind:      DocumentPost,
+		URL:       p.URL,
+		Author:    DocumentAuthor{Username: p.Username},
+		Title:     p.Title,
+		Text:      p.Body,
+		CreatedAt: p.CreatedAt.UTC(),
+		ScrapedAt: p.ScrapedAt.UTC(),
+		Engagement: DocumentEngagement{
+			Likes:   int64(p.UpVotes),
+			Replies: int64(p.NumberOfComments),
+		},
+		Community: p.CommunityName,
+		ThreadID:  p.ID,
+	}
+}
+
+// ToDocument converts a Reddit comment to a normalized Document
+func (c *RedditComment) ToDocument() Document {
+	return Document{
+		ID:        c.ID,
+		Platform:  PlatformReddit,
+		Kind:      DocumentComment,
+		URL:       c.URL,
+		Author:    DocumentAuthor{Username: c.Username},
+		Text:      c.Body,
+		CreatedAt: c.CreatedAt.UTC(),
+		ScrapedAt: c.ScrapedAt.UTC(),
+		Engagement: DocumentEngagement{
+			Likes:   int64(c.UpVotes),
+			Replies: int64(c.NumberOfReplies),
+		},
+		Community: c.CommunityName,
+		ParentID:  c.ParentID,
+		ThreadID:  redditPostIDFromURL(c.URL),
+	}
+}
+
+// ToDocument converts a Reddit item to a normalized Document. Only posts and comments can be converted.
+func (t *RedditItem) ToDocument() (Document, bool) {
+	switch {
+	case t.Post != nil:
+		return t.Post.ToDocument(), true
+	case t.Comment != nil:
+		return t.Comment.ToDocument(), true
+	default:
+		return Document{}, false
+	}
+}
+
+// redditPostIDFromURL extracts the post fullname (e.g. "t3_abc123") from a Reddit post or comment URL
+func redditPostIDFromURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i, s := range segments {
+		if s == "comments" && i+1 < len(segments) && segments[i+1] != "" {
+			return "t3_" + segments[i+1]
+		}
+	}
+	return ""
+}
+
+// ToDocument converts a TikTok search result to a normalized Document
+func (t *TikTokSearchByQueryResult) ToDocument() Document {
+	doc := Document{
+		ID:       t.ID,
+		Platform: PlatformTikTok,
+		Kind:     DocumentVideo,
+		URL:      t.URL,
+		Author:   DocumentAuthor{ID: t.AuthorID, Username: t.Author, DisplayName: t.Nickname},
+		Text:     t.Desc,
+		Engagement: DocumentEngagement{
+			Likes:   t.Stats.DiggCount,
+			Replies: t.Stats.CommentCount,
+			Shares:  t.Stats.ShareCount,
+			Views:   t.Stats.PlayCount,
+		},
+		ThreadID: t.ID,
+	}
+
+	if doc.URL == "" && t.Author != "" && t.ID != "" {
+		doc.URL = fmt.Sprintf("https://%s/@%s/video/%s", tiktokCanonicalHost, t.Author, t.ID)
+	}
+
+	// createTime is a Unix timestamp in seconds
+	if secs, err := strconv.ParseInt(t.CreateTime, 10, 64); err == nil && secs > 0 {
+		doc.CreatedAt = time.Unix(secs, 0).UTC()
+	}
+
+	if t.Video.PlayAddr != "" || t.Video.Cover != "" {
+		doc.Media = []DocumentMedia{{
+			Type:       MediaVideo,
+			URL:        t.Video.PlayAddr,
+			PreviewURL: t.Video.Cover,
+			Duration:   t.Video.Duration,
+		}}
+	}
+
+	doc.Hashtags = t.Hashtags()
+
+	return doc
+}
+
+// tiktokTranscriptIDPrefix distinguishes the ID of a transcript from the ID of its video, so that the two documents do
+// not have the same Key
+const tiktokTranscriptIDPrefix = "transcript:"
+
+// ToDocument converts a TikTok transcription to a normalized Document.
+// The ID is "transcript:" followed by the video ID if it can be extracted from the original URL, or by the URL itself
+// otherwise. ThreadID is the video ID, which links the transcript to the video document.
+func (t *TikTokTranscriptionResult) ToDocument() Document {
+	doc := Document{
+		ID:       tiktokTranscriptIDPrefix + t.OriginalURL,
+		Platform: PlatformTikTok,
+		Kind:     DocumentTranscript,
+		URL:      t.OriginalURL,
+		Title:    t.VideoTitle,
+		Text:     t.TranscriptionText,
+		Language: t.DetectedLanguage,
+	}
+
+	if u, err := ParseTikTokURL(t.OriginalURL); err == nil && u.IsVideo() {
+		doc.ID = tiktokTranscriptIDPrefix + u.VideoID
+		doc.ThreadID = u.VideoID
+		doc.URL = u.CanonicalURL()
+		doc.Author.Username = u.Username
+	}
+
+	if t.ThumbnailURL != "" {
+		doc.Media = []DocumentMedia{{Type: MediaVideo, URL: doc.URL, PreviewURL: t.ThumbnailURL}}
+	}
+
+	return doc
+}
+
+// ToDocument converts a scraped web page to a normalized Document. The ID is the canonical URL of the page.
+func (w *WebScraperResult) ToDocument() Document {
+	id := w.Metadata.CanonicalURL
+	if id == "" {
+		id = w.URL
+	}
+
+	doc := Document{
+		ID:        id,
+		Platform:  PlatformWeb,
+		Kind:      DocumentPage,
+		URL:       w.URL,
+		Title:     w.Metadata.Title,
+		Text:      w.Text,
+		ScrapedAt: w.Crawl.LoadedTime.UTC(),
+	}
+
+	if w.Metadata.Author != nil {
+		doc.Author.DisplayName = *w.Metadata.Author
+	}
+	if w.Metadata.LanguageCode != nil {
+		doc.Language = *w.Metadata.LanguageCode
+	}
+
+	return doc
+}
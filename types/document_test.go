@@ -0,0 +1,119 @@
+package types_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("Document", func() {
+	It("should convert a tweet", func() {
+		created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+		tweet := types.TweetResult{
+			TweetID:        "200",
+			ConversationID: "100",
+			UserID:         "42",
+			Username:       "masa_finance",
+			Text:           "hello #world",
+			CreatedAt:      created,
+			IsReply:        true,
+			Likes:          3,
+			Retweets:       2,
+			Replies:        1,
+			Views:          50,
+			Hashtags:       []string{"world"},
+			Lang:           "en",
+			Photos:         []types.Photo{{ID: "p", URL: "https://pbs.twimg.com/p.jpg"}},
+			PublicMetrics:  types.PublicMetrics{QuoteCount: 4, LikeCount: 1},
+		}
+		doc := tweet.ToDocument()
+		Expect(doc.Key()).To(Equal("twitter:200"))
+		Expect(doc.Kind).To(Equal(types.DocumentComment))
+		Expect(doc.URL).To(Equal("https://x.com/masa_finance/status/200"))
+		Expect(doc.Author).To(Equal(types.DocumentAuthor{ID: "42", Username: "masa_finance"}))
+		Expect(doc.CreatedAt).To(Equal(created))
+		Expect(doc.Engagement).To(Equal(types.DocumentEngagement{Likes: 3, Replies: 1, Shares: 2, Quotes: 4, Views: 50}))
+		Expect(doc.ThreadID).To(Equal("100"))
+		Expect(doc.Media).To(Equal([]types.DocumentMedia{{Type: types.MediaImage, URL: "https://pbs.twimg.com/p.jpg"}}))
+	})
+
+	It("should convert Reddit posts and comments", func() {
+		post := types.RedditItem{Post: &types.RedditPost{ID: "t3_abc", Title: "Title", Body: "Body", UpVotes: 5, CommunityName: "r/golang"}}
+		doc, ok := post.ToDocument()
+		Expect(ok).To(BeTrue())
+		Expect(doc.Kind).To(Equal(types.DocumentPost))
+		Expect(doc.Title).To(Equal("Title"))
+		Expect(doc.Engagement.Likes).To(Equal(int64(5)))
+		Expect(doc.Community).To(Equal("r/golang"))
+
+		comment := types.RedditComment{
+			ID:       "t1_xyz",
+			ParentID: "t3_abc",
+			URL:      "https://www.reddit.com/r/golang/comments/abc/title/xyz/",
+			Body:     "Comment",
+		}
+		doc = comment.ToDocument()
+		Expect(doc.Kind).To(Equal(types.DocumentComment))
+		Expect(doc.ParentID).To(Equal("t3_abc"))
+		Expect(doc.ThreadID).To(Equal("t3_abc"))
+
+		_, ok = (&types.RedditItem{User: &types.RedditUser{}}).ToDocument()
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should convert a TikTok search result", func() {
+		result := types.TikTokSearchByQueryResult{
+			ID:         "123",
+			Desc:       "dance #fyp",
+			CreateTime: "1700000000",
+			Author:     "user",
+			AuthorID:   "99",
+			Stats:      types.TikTokStats{DiggCount: 10, CommentCount: 2, ShareCount: 3, PlayCount: 100},
+			Video:      types.TikTokVideo{PlayAddr: "https://v.tiktok.com/play", Cover: "https://p.tiktok.com/c.jpg", Duration: 15},
+			TextExtra:  []types.TikTokTextExtra{{HashtagName: "fyp"}},
+		}
+		doc := result.ToDocument()
+		Expect(doc.URL).To(Equal("https://www.tiktok.com/@user/video/123"))
+		Expect(doc.CreatedAt).To(Equal(time.Unix(1700000000, 0).UTC()))
+		Expect(doc.Engagement.Views).To(Equal(int64(100)))
+		Expect(doc.Media[0].Duration).To(Equal(15))
+		Expect(doc.Hashtags).To(Equal([]string{"fyp"}))
+	})
+
+	It("should convert a TikTok transcription", func() {
+		result := types.TikTokTranscriptionResult{
+			TranscriptionText: "hello",
+			DetectedLanguage:  "en",
+			OriginalURL:       "https://www.tiktok.com/@user/video/123?lang=en",
+		}
+		doc := result.ToDocument()
+		Expect(doc.ID).To(Equal("transcript:123"))
+		Expect(doc.ThreadID).To(Equal("123"))
+		Expect(doc.Kind).To(Equal(types.DocumentTranscript))
+		Expect(doc.URL).To(Equal("https://www.tiktok.com/@user/video/123"))
+		Expect(doc.Author.Username).To(Equal("user"))
+		Expect(doc.Language).To(Equal("en"))
+
+		video := types.TikTokSearchByQueryResult{ID: "123", Author: "user"}
+		Expect(doc.Key()).ToNot(Equal(video.ToDocument().Key()))
+	})
+
+	It("should convert a web page", func() {
+		lang := "en"
+		loaded := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+		result := types.WebScraperResult{
+			URL:      "https://example.com/?utm=1",
+			Crawl:    types.WebCrawlInfo{LoadedTime: loaded},
+			Metadata: types.WebMetadata{CanonicalURL: "https://example.com/", Title: "Example", LanguageCode: &lang},
+			Text:     "content",
+		}
+		doc := result.ToDocument()
+		Expect(doc.Key()).To(Equal("web:https://example.com/"))
+		Expect(doc.Title).To(Equal("Example"))
+		Expect(doc.Language).To(Equal("en"))
+		Expect(doc.ScrapedAt).To(Equal(loaded))
+	})
+})
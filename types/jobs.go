@@ -49,13 +49,15 @@ const (
 	TwitterCredentialJob JobType = "twitter-credential" // Twitter scraping with credentials
 	TwitterApiJob        JobType = "twitter-api"        // Twitter scraping with API keys
 	TwitterApifyJob      JobType = "twitter-apify"      // Twitter scraping with Apify
-	LinkedInJob          JobType = "linkedin"           // LinkedIn scraping, keeping for unmarshalling logic
+	LinkedInJob          JobType = "linkedin"           // LinkedIn scraping
 	RedditJob            JobType = "reddit"             // Reddit scraping with Apify
+	LLMJob               JobType = "llm"                // LLM processing of scraped datasets with Apify
 )
 
 // Capability constants - typed to prevent typos and enable discoverability
 const (
 	CapScraper             Capability = "scraper"
+	CapSitemap             Capability = "sitemap"
 	CapTelemetry           Capability = "telemetry"
 	CapTranscription       Capability = "transcription"
 	CapSearchByQuery       Capability = "searchbyquery"
@@ -75,11 +77,15 @@ const (
 	CapGetFollowers        Capability = "getfollowers"
 	CapGetSpace            Capability = "getspace"
 	CapGetProfile          Capability = "getprofile"
+	CapGetCompany          Capability = "getcompany"
+	CapSearchJobs          Capability = "searchjobs"
 	// Reddit capabilities
 	CapScrapeUrls        Capability = "scrapeurls"
 	CapSearchPosts       Capability = "searchposts"
 	CapSearchUsers       Capability = "searchusers"
 	CapSearchCommunities Capability = "searchcommunities"
+	// LLM capabilities
+	CapDatasetProcessor Capability = "datasetprocessor"
 
 	CapEmpty Capability = ""
 )
@@ -88,7 +94,7 @@ const (
 var (
 	AlwaysAvailableTelemetryCaps = []Capability{CapTelemetry, CapEmpty}
 	AlwaysAvailableTiktokCaps    = []Capability{CapTranscription, CapEmpty}
-	AlwaysAvailableLinkedInCaps  = []Capability{CapSearchByQuery, CapGetProfile, CapEmpty}
+	AlwaysAvailableLinkedInCaps  = []Capability{CapSearchByQuery, CapGetProfile, CapGetCompany, CapSearchJobs, CapEmpty}
 
 	// AlwaysAvailableCapabilities defines the job capabilities that are always available regardless of configuration
 	AlwaysAvailableCapabilities = WorkerCapabilities{
@@ -118,7 +124,10 @@ var (
 	RedditCaps = []Capability{CapScrapeUrls, CapSearchPosts, CapSearchUsers, CapSearchCommunities}
 
 	// WebCaps are all the Web capabilities (only available with Apify)
-	WebCaps = []Capability{CapScraper, CapEmpty}
+	WebCaps = []Capability{CapScraper, CapSitemap, CapEmpty}
+
+	// LLMCaps are all the LLM capabilities (only available with Apify)
+	LLMCaps = []Capability{CapDatasetProcessor, CapEmpty}
 )
 
 // JobCapabilityMap defines which capabilities are valid for each job type
@@ -146,9 +155,15 @@ var JobCapabilityMap = map[JobType][]Capability{
 		TiktokSearchCaps,
 	),
 
+	// LinkedIn job capabilities
+	LinkedInJob: AlwaysAvailableLinkedInCaps,
+
 	// Reddit job capabilities
 	RedditJob: RedditCaps,
 
+	// LLM job capabilities
+	LLMJob: LLMCaps,
+
 	// Telemetry job capabilities
 	TelemetryJob: AlwaysAvailableTelemetryCaps,
 }
@@ -161,6 +176,8 @@ var JobDefaultCapabilityMap = map[JobType]Capability{
 	TwitterApifyJob:      CapGetFollowers,
 	WebJob:               CapScraper,
 	TiktokJob:            CapTranscription,
+	LinkedInJob:          CapSearchByQuery,
 	RedditJob:            CapScrapeUrls,
+	LLMJob:               CapDatasetProcessor,
 	TelemetryJob:         CapTelemetry,
 }
@@ -1,6 +1,13 @@
 // Package types provides shared types between tee-worker and tee-indexer
 package types
 
+import (
+	"fmt"
+	"time"
+
+	"github.com/masa-finance/tee-types/pkg/util"
+)
+
 // LinkedInProfileResult defines the structure of a LinkedIn profile search result
 type LinkedInProfileResult struct {
 	PublicIdentifier string `json:"public_identifier"` // Username/slug in profile URL
@@ -14,22 +21,81 @@ type LinkedInProfileResult struct {
 
 // Experience defines the structure for a single entry in a user's work experience
 type Experience struct {
-	Title       string `json:"title"`
-	CompanyName string `json:"company_name"`
-	Location    string `json:"location,omitempty"`
-	StartDate   string `json:"start_date,omitempty"`
-	EndDate     string `json:"end_date,omitempty"`
-	Description string `json:"description,omitempty"`
+	Title       string    `json:"title"`
+	CompanyName string    `json:"company_name"`
+	Location    string    `json:"location,omitempty"`
+	StartDate   YearMonth `json:"start_date,omitzero"`
+	EndDate     YearMonth `json:"end_date,omitzero"` // Present for current positions
+	Description string    `json:"description,omitempty"`
 }
 
 // Education defines the structure for a single entry in a user's education history
 type Education struct {
-	SchoolName   string `json:"school_name"`
-	DegreeName   string `json:"degree_name,omitempty"`
-	FieldOfStudy string `json:"field_of_study,omitempty"`
-	StartDate    string `json:"start_date,omitempty"`
-	EndDate      string `json:"end_date,omitempty"`
-	Description  string `json:"description,omitempty"`
+	SchoolName   string    `json:"school_name"`
+	DegreeName   string    `json:"degree_name,omitempty"`
+	FieldOfStudy string    `json:"field_of_study,omitempty"`
+	StartDate    YearMonth `json:"start_date,omitzero"`
+	EndDate      YearMonth `json:"end_date,omitzero"`
+	Description  string    `json:"description,omitempty"`
+}
+
+// IsCurrent returns true if the position is ongoing
+func (e *Experience) IsCurrent() bool {
+	return e.EndDate.Present || (e.EndDate.IsZero() && !e.StartDate.IsZero())
+}
+
+// DurationMonths returns the length of the position in months, counting both the start and the end month as LinkedIn
+// does (e.g. "Jan 2020 - Mar 2020" is 3 months). Ongoing positions are measured up to now. It returns 0 if the start
+// date is unknown.
+func (e *Experience) DurationMonths(now time.Time) int {
+	return monthsBetween(e.StartDate, e.EndDate, now)
+}
+
+// DurationMonths returns the length of the studies in months, see Experience.DurationMonths
+func (e *Education) DurationMonths(now time.Time) int {
+	return monthsBetween(e.StartDate, e.EndDate, now)
+}
+
+func monthsBetween(start, end YearMonth, now time.Time) int {
+	if !start.Valid() || start.Present {
+		return 0
+	}
+	if end.IsZero() {
+		end.Present = true
+	} else if !end.Valid() {
+		return 0
+	}
+
+	startTime := start.Time(now)
+	endTime := end.Time(now)
+	months := (endTime.Year()-startTime.Year())*12 + int(endTime.Month()-startTime.Month()) + 1
+	return max(months, 0)
+}
+
+// Certification defines the structure for a single license or certification entry
+type Certification struct {
+	Name           string    `json:"name"`
+	Authority      string    `json:"authority,omitempty"` // Issuing organization
+	LicenseNumber  string    `json:"license_number,omitempty"`
+	IssueDate      YearMonth `json:"issue_date,omitzero"`
+	ExpirationDate YearMonth `json:"expiration_date,omitzero"`
+	URL            string    `json:"url,omitempty"`
+}
+
+// Language defines the structure for a single language entry
+type Language struct {
+	Name        string `json:"name"`
+	Proficiency string `json:"proficiency,omitempty"` // e.g. "Native or bilingual proficiency"
+}
+
+// VolunteerExperience defines the structure for a single entry in a user's volunteer work
+type VolunteerExperience struct {
+	Role         string    `json:"role"`
+	Organization string    `json:"organization"`
+	Cause        string    `json:"cause,omitempty"`
+	StartDate    YearMonth `json:"start_date,omitzero"`
+	EndDate      YearMonth `json:"end_date,omitzero"`
+	Description  string    `json:"description,omitempty"`
 }
 
 // Skill defines the structure for a single skill entry
@@ -39,14 +105,135 @@ type Skill struct {
 
 // LinkedInFullProfileResult defines the structure for a detailed LinkedIn profile
 type LinkedInFullProfileResult struct {
-	PublicIdentifier  string       `json:"public_identifier"`
-	URN               string       `json:"urn"`
-	FullName          string       `json:"full_name"`
-	Headline          string       `json:"headline"`
-	Location          string       `json:"location"`
-	Summary           string       `json:"summary,omitempty"`
-	ProfilePictureURL string       `json:"profile_picture_url,omitempty"`
-	Experiences       []Experience `json:"experiences,omitempty"`
-	Education         []Education  `json:"education,omitempty"`
-	Skills            []Skill      `json:"skills,omitempty"`
+	PublicIdentifier  string                `json:"public_identifier"`
+	URN               string                `json:"urn"`
+	FullName          string                `json:"full_name"`
+	Headline          string                `json:"headline"`
+	Location          string                `json:"location"`
+	Summary           string                `json:"summary,omitempty"`
+	ProfilePictureURL string                `json:"profile_picture_url,omitempty"`
+	Experiences       []Experience          `json:"experiences,omitempty"`
+	Education         []Education           `json:"education,omitempty"`
+	Skills            []Skill               `json:"skills,omitempty"`
+	Certifications    []Certification       `json:"certifications,omitempty"`
+	Languages         []Language            `json:"languages,omitempty"`
+	Volunteering      []VolunteerExperience `json:"volunteering,omitempty"`
+	ConnectionCount   int                   `json:"connection_count,omitempty"` // LinkedIn caps the displayed count at 500
+	FollowerCount     int                   `json:"follower_count,omitempty"`
+}
+
+// CurrentPositions returns the ongoing positions of the profile, in the order they are listed
+func (p *LinkedInFullProfileResult) CurrentPositions() []Experience {
+	var ret []Experience
+	for _, e := range p.Experiences {
+		if e.IsCurrent() {
+			ret = append(ret, e)
+		}
+	}
+	return ret
+}
+
+// CurrentPosition returns the first ongoing position of the profile, or nil if there is none
+func (p *LinkedInFullProfileResult) CurrentPosition() *Experience {
+	for i := range p.Experiences {
+		if p.Experiences[i].IsCurrent() {
+			return &p.Experiences[i]
+		}
+	}
+	return nil
+}
+
+// LinkedInCompanySize is the employee count range of a company, as displayed on its page (e.g. "51-200 employees")
+type LinkedInCompanySize struct {
+	Min int `json:"min"`
+	Max int `json:"max,omitempty"` // 0 for the open-ended top range, e.g. "10,001+ employees"
+}
+
+// String formats the size range the way LinkedIn displays it
+func (s LinkedInCompanySize) String() string {
+	switch {
+	case s.Min == 0 && s.Max == 0:
+		return ""
+	case s.Max == 0:
+		return fmt.Sprintf("%d+ employees", s.Min)
+	default:
+		return fmt.Sprintf("%d-%d employees", s.Min, s.Max)
+	}
+}
+
+// LinkedInCompanyResult defines the structure of a LinkedIn company page
+type LinkedInCompanyResult struct {
+	Slug          string              `json:"slug"` // Company identifier in the page URL
+	URN           string              `json:"urn"`
+	Name          string              `json:"name"`
+	Tagline       string              `json:"tagline,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Industry      string              `json:"industry,omitempty"`
+	CompanyType   string              `json:"company_type,omitempty"` // e.g. "Public Company", "Privately Held"
+	Size          LinkedInCompanySize `json:"size"`
+	Headquarters  string              `json:"headquarters,omitempty"` // e.g. "Redmond, Washington"
+	Website       string              `json:"website,omitempty"`
+	FollowerCount int                 `json:"follower_count,omitempty"`
+	Specialties   []string            `json:"specialties,omitempty"`
+	FoundedYear   int                 `json:"founded_year,omitempty"`
+	LogoURL       string              `json:"logo_url,omitempty"`
+	CompanyURL    string              `json:"company_url"` // Full LinkedIn company page URL
+}
+
+type LinkedInWorkplaceType string
+
+const (
+	LinkedInWorkplaceOnsite LinkedInWorkplaceType = "onsite"
+	LinkedInWorkplaceRemote LinkedInWorkplaceType = "remote"
+	LinkedInWorkplaceHybrid LinkedInWorkplaceType = "hybrid"
+)
+
+var AllLinkedInWorkplaceTypes = util.NewSet(LinkedInWorkplaceOnsite, LinkedInWorkplaceRemote, LinkedInWorkplaceHybrid)
+
+type LinkedInExperienceLevel string
+
+const (
+	LinkedInExperienceInternship LinkedInExperienceLevel = "internship"
+	LinkedInExperienceEntry      LinkedInExperienceLevel = "entry"
+	LinkedInExperienceAssociate  LinkedInExperienceLevel = "associate"
+	LinkedInExperienceMidSenior  LinkedInExperienceLevel = "mid_senior"
+	LinkedInExperienceDirector   LinkedInExperienceLevel = "director"
+	LinkedInExperienceExecutive  LinkedInExperienceLevel = "executive"
+)
+
+var AllLinkedInExperienceLevels = util.NewSet(
+	LinkedInExperienceInternship,
+	LinkedInExperienceEntry,
+	LinkedInExperienceAssociate,
+	LinkedInExperienceMidSenior,
+	LinkedInExperienceDirector,
+	LinkedInExperienceExecutive,
+)
+
+type LinkedInDatePosted string
+
+const (
+	LinkedInPostedAnyTime   LinkedInDatePosted = "any"
+	LinkedInPostedPastMonth LinkedInDatePosted = "past_month"
+	LinkedInPostedPastWeek  LinkedInDatePosted = "past_week"
+	LinkedInPostedPastDay   LinkedInDatePosted = "past_24h"
+)
+
+var AllLinkedInDatePosted = util.NewSet(LinkedInPostedAnyTime, LinkedInPostedPastMonth, LinkedInPostedPastWeek, LinkedInPostedPastDay)
+
+// LinkedInJobPostingResult defines the structure of a LinkedIn job search result
+type LinkedInJobPostingResult struct {
+	JobID           string                  `json:"job_id"`
+	Title           string                  `json:"title"`
+	CompanyName     string                  `json:"company_name"`
+	CompanySlug     string                  `json:"company_slug,omitempty"`
+	Location        string                  `json:"location"`
+	WorkplaceType   LinkedInWorkplaceType   `json:"workplace_type,omitempty"`
+	EmploymentType  string                  `json:"employment_type,omitempty"` // e.g. "Full-time", "Contract"
+	ExperienceLevel LinkedInExperienceLevel `json:"experience_level,omitempty"`
+	PostedAt        time.Time               `json:"posted_at"`
+	ApplicantCount  int                     `json:"applicant_count,omitempty"`
+	Salary          string                  `json:"salary,omitempty"` // As displayed, e.g. "$120K/yr - $150K/yr"
+	Description     string                  `json:"description,omitempty"`
+	JobURL          string                  `json:"job_url"` // Full LinkedIn job posting URL
 }
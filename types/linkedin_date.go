@@ -0,0 +1,128 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrYearMonthInvalid = errors.New("invalid date, expected e.g. \"Jan 2020\", \"2020\" or \"Present\"")
+
+const yearMonthPresent = "Present"
+
+// YearMonth is a month-precision date as used by LinkedIn for experiences, education and certifications.
+// Month is 0 if only the year is known. The zero value means the date is unknown.
+type YearMonth struct {
+	Year    int
+	Month   time.Month
+	Present bool   // The date is "Present", i.e. the position or studies are ongoing
+	Raw     string // The original text of a date that could not be parsed, e.g. "Summer 2019"
+}
+
+// ParseYearMonth parses the date formats found in LinkedIn profiles: "Jan 2020", "January 2020", "2020", "2020-01",
+// "01/2020" and "Present". An empty string parses to the zero YearMonth.
+func ParseYearMonth(s string) (YearMonth, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return YearMonth{}, nil
+	case strings.EqualFold(s, yearMonthPresent), strings.EqualFold(s, "current"), strings.EqualFold(s, "now"):
+		return YearMonth{Present: true}, nil
+	}
+
+	for _, layout := range []string{"Jan 2006", "January 2006", "2006-01", "01/2006", "1/2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return YearMonth{Year: t.Year(), Month: t.Month()}, nil
+		}
+	}
+
+	if year, err := strconv.Atoi(s); err == nil && year > 0 && year < 10000 {
+		return YearMonth{Year: year}, nil
+	}
+
+	return YearMonth{}, fmt.Errorf("%w, got: %q", ErrYearMonthInvalid, s)
+}
+
+// IsZero returns true if the date is missing
+func (y YearMonth) IsZero() bool {
+	return y == YearMonth{}
+}
+
+// Valid returns true if the date was parsed, i.e. it is neither missing nor kept only as Raw text
+func (y YearMonth) Valid() bool {
+	return y.Year > 0 || y.Present
+}
+
+// String formats the date the way LinkedIn displays it, e.g. "Jan 2020", "2020" or "Present"
+func (y YearMonth) String() string {
+	switch {
+	case y.Present:
+		return yearMonthPresent
+	case !y.Valid():
+		return y.Raw
+	case y.Month == 0:
+		return strconv.Itoa(y.Year)
+	default:
+		return fmt.Sprintf("%s %d", y.Month.String()[:3], y.Year)
+	}
+}
+
+// Time returns the first day of the month in UTC (of January if the month is unknown), now for a Present date, or the
+// zero time for a missing or unparsed date
+func (y YearMonth) Time(now time.Time) time.Time {
+	switch {
+	case y.Present:
+		return now
+	case !y.Valid():
+		return time.Time{}
+	default:
+		return time.Date(y.Year, max(y.Month, time.January), 1, 0, 0, 0, 0, time.UTC)
+	}
+}
+
+// MarshalJSON encodes the date as an ISO "2020-01" string, "2020" if the month is unknown or "Present". Unparsed
+// dates keep their Raw text, a missing date is null, and a month without a year is an error.
+func (y YearMonth) MarshalJSON() ([]byte, error) {
+	switch {
+	case y.IsZero():
+		return []byte("null"), nil
+	case y.Present:
+		return json.Marshal(yearMonthPresent)
+	case y.Year <= 0 && y.Raw == "":
+		return nil, fmt.Errorf("%w, got month %d without a year", ErrYearMonthInvalid, y.Month)
+	case !y.Valid():
+		return json.Marshal(y.Raw)
+	case y.Month == 0:
+		return json.Marshal(strconv.Itoa(y.Year))
+	default:
+		return json.Marshal(fmt.Sprintf("%04d-%02d", y.Year, y.Month))
+	}
+}
+
+// UnmarshalJSON decodes a date string (see ParseYearMonth) or a {"year": 2020, "month": 1} object. Like the TikTok
+// types it is tolerant: a date that cannot be parsed is kept in Raw instead of failing the whole result.
+func (y *YearMonth) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		parsed, err := ParseYearMonth(s)
+		if err != nil {
+			parsed = YearMonth{Raw: strings.TrimSpace(s)}
+		}
+		*y = parsed
+		return nil
+	}
+
+	var obj struct {
+		Year  int `json:"year"`
+		Month int `json:"month"`
+	}
+	if err := json.Unmarshal(data, &obj); err != nil || obj.Month < 0 || obj.Month > 12 || (obj.Year <= 0 && obj.Month != 0) {
+		*y = YearMonth{Raw: string(data)}
+		return nil
+	}
+	*y = YearMonth{Year: obj.Year, Month: time.Month(obj.Month)}
+	return nil
+}
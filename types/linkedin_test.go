@@ -0,0 +1,160 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("LinkedIn", func() {
+	DescribeTable("ParseYearMonth",
+		func(input string, expected types.YearMonth, formatted string) {
+			ym, err := types.ParseYearMonth(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ym).To(Equal(expected))
+			Expect(ym.String()).To(Equal(formatted))
+		},
+		Entry("short month", "Jan 2020", types.YearMonth{Year: 2020, Month: time.January}, "Jan 2020"),
+		Entry("long month", "September 2019", types.YearMonth{Year: 2019, Month: time.September}, "Sep 2019"),
+		Entry("year only", "2020", types.YearMonth{Year: 2020}, "2020"),
+		Entry("ISO", "2021-03", types.YearMonth{Year: 2021, Month: time.March}, "Mar 2021"),
+		Entry("numeric", "3/2021", types.YearMonth{Year: 2021, Month: time.March}, "Mar 2021"),
+		Entry("present", " present ", types.YearMonth{Present: true}, "Present"),
+		Entry("empty", "", types.YearMonth{}, ""),
+	)
+
+	It("should reject unknown date formats", func() {
+		_, err := types.ParseYearMonth("last year")
+		Expect(errors.Is(err, types.ErrYearMonthInvalid)).To(BeTrue())
+	})
+
+	It("should decode dates from strings and objects", func() {
+		var exp types.Experience
+		Expect(json.Unmarshal([]byte(`{"title":"Engineer","start_date":{"year":2020,"month":2},"end_date":"Present"}`), &exp)).To(Succeed())
+		Expect(exp.StartDate).To(Equal(types.YearMonth{Year: 2020, Month: time.February}))
+		Expect(exp.EndDate.Present).To(BeTrue())
+
+		data, err := json.Marshal(exp)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring(`"start_date":"2020-02","end_date":"Present"`))
+
+		data, err = json.Marshal(types.Experience{Title: "CTO"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).NotTo(ContainSubstring("date"))
+	})
+
+	It("should marshal dates in ISO form", func() {
+		for _, input := range []string{`"2020-01"`, `"2020"`, `"Present"`, `null`} {
+			var ym types.YearMonth
+			Expect(json.Unmarshal([]byte(input), &ym)).To(Succeed())
+			data, err := json.Marshal(ym)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(input))
+		}
+
+		data, err := json.Marshal(types.YearMonth{Year: 2019, Month: time.November})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`"2019-11"`))
+	})
+
+	It("should not marshal a month without a year", func() {
+		_, err := json.Marshal(types.YearMonth{Month: time.March})
+		Expect(errors.Is(err, types.ErrYearMonthInvalid)).To(BeTrue())
+	})
+
+	DescribeTable("keeping dates that cannot be parsed",
+		func(input string, raw string) {
+			var exp types.Experience
+			Expect(json.Unmarshal([]byte(`{"title":"CTO","start_date":`+input+`,"end_date":"Present"}`), &exp)).To(Succeed())
+			Expect(exp.Title).To(Equal("CTO"))
+			Expect(exp.StartDate).To(Equal(types.YearMonth{Raw: raw}))
+			Expect(exp.StartDate.Valid()).To(BeFalse())
+			Expect(exp.EndDate.Present).To(BeTrue())
+			Expect(exp.DurationMonths(time.Now())).To(BeZero())
+
+			data, err := json.Marshal(exp)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(ContainSubstring(`"start_date":` + `"` + strings.ReplaceAll(raw, `"`, `\"`) + `"`))
+		},
+		Entry("season", `"Summer 2019"`, "Summer 2019"),
+		Entry("range", `"2019 - 2021"`, "2019 - 2021"),
+		Entry("localized month", `"janv. 2020"`, "janv. 2020"),
+		Entry("invalid object", `{"year":2020,"month":13}`, `{"year":2020,"month":13}`),
+		Entry("object without a year", `{"year":0,"month":3}`, `{"year":0,"month":3}`),
+	)
+
+	It("should compute experience durations", func() {
+		now := time.Date(2024, 6, 15, 0, 0, 0, 0, time.UTC)
+		jan2020, _ := types.ParseYearMonth("Jan 2020")
+		mar2020, _ := types.ParseYearMonth("Mar 2020")
+
+		past := types.Experience{StartDate: jan2020, EndDate: mar2020}
+		Expect(past.IsCurrent()).To(BeFalse())
+		Expect(past.DurationMonths(now)).To(Equal(3))
+
+		current := types.Experience{StartDate: jan2020, EndDate: types.YearMonth{Present: true}}
+		Expect(current.IsCurrent()).To(BeTrue())
+		Expect(current.DurationMonths(now)).To(Equal(54))
+
+		Expect((&types.Experience{}).DurationMonths(now)).To(Equal(0))
+	})
+
+	It("should decode the extra full profile sections", func() {
+		var profile types.LinkedInFullProfileResult
+		input := `{
+			"public_identifier": "jdoe",
+			"experiences": [
+				{"title": "Advisor", "company_name": "Old", "start_date": "2015", "end_date": "2018"},
+				{"title": "CTO", "company_name": "Acme", "start_date": "Jan 2020", "end_date": "Present"}
+			],
+			"certifications": [{"name": "CKA", "authority": "CNCF", "issue_date": "Jun 2021"}],
+			"languages": [{"name": "English", "proficiency": "Native or bilingual proficiency"}],
+			"volunteering": [{"role": "Mentor", "organization": "Code Club", "start_date": "2019"}],
+			"connection_count": 500,
+			"follower_count": 1200
+		}`
+		Expect(json.Unmarshal([]byte(input), &profile)).To(Succeed())
+		Expect(profile.Certifications[0].IssueDate.Month).To(Equal(time.June))
+		Expect(profile.Languages[0].Name).To(Equal("English"))
+		Expect(profile.Volunteering[0].StartDate.Year).To(Equal(2019))
+		Expect(profile.ConnectionCount).To(Equal(500))
+		Expect(profile.CurrentPositions()).To(HaveLen(1))
+		Expect(profile.CurrentPosition().CompanyName).To(Equal("Acme"))
+		Expect(profile.ToProfile().Followers).To(Equal(int64(1200)))
+	})
+
+	It("should decode a company page", func() {
+		var company types.LinkedInCompanyResult
+		input := `{
+			"slug": "microsoft",
+			"name": "Microsoft",
+			"industry": "Software Development",
+			"size": {"min": 10001},
+			"headquarters": "Redmond, Washington",
+			"website": "https://news.microsoft.com/",
+			"follower_count": 25000000,
+			"specialties": ["Business Software", "Cloud Computing"],
+			"founded_year": 1975
+		}`
+		Expect(json.Unmarshal([]byte(input), &company)).To(Succeed())
+		Expect(company.Size.String()).To(Equal("10001+ employees"))
+		Expect(company.Specialties).To(HaveLen(2))
+		Expect(company.FoundedYear).To(Equal(1975))
+		Expect(types.LinkedInCompanySize{Min: 51, Max: 200}.String()).To(Equal("51-200 employees"))
+	})
+
+	It("should decode a job posting", func() {
+		var posting types.LinkedInJobPostingResult
+		input := `{"job_id":"3912345678","title":"Senior Go Engineer","company_name":"Acme","workplace_type":"remote","employment_type":"Full-time","posted_at":"2025-01-02T00:00:00Z","applicant_count":42}`
+		Expect(json.Unmarshal([]byte(input), &posting)).To(Succeed())
+		Expect(posting.WorkplaceType).To(Equal(types.LinkedInWorkplaceRemote))
+		Expect(posting.PostedAt).To(Equal(time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)))
+		Expect(posting.ApplicantCount).To(Equal(42))
+	})
+})
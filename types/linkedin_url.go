@@ -0,0 +1,153 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrLinkedInURLInvalid = errors.New("invalid URL")
+	ErrNotLinkedInURL     = errors.New("not a LinkedIn URL")
+)
+
+type LinkedInURLKind string
+
+const (
+	LinkedInProfileURL LinkedInURLKind = "profile"
+	LinkedInCompanyURL LinkedInURLKind = "company"
+	LinkedInSchoolURL  LinkedInURLKind = "school"
+	LinkedInJobURL     LinkedInURLKind = "job"
+	LinkedInOtherURL   LinkedInURLKind = "other" // any other page on linkedin.com
+)
+
+const linkedInCanonicalHost = "www.linkedin.com"
+
+var (
+	// LinkedIn custom URLs are 3-100 letters or numbers, plus hyphens. Older ones may contain underscores.
+	linkedInIdentifierRe = regexp.MustCompile(`^[\p{L}\p{N}_-]{3,100}$`)
+	linkedInSlugRe       = regexp.MustCompile(`^[\p{L}\p{N}_-]{1,100}$`)
+	linkedInJobIDRe      = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// LinkedInURL is a parsed and classified LinkedIn URL
+type LinkedInURL struct {
+	Kind             LinkedInURLKind
+	PublicIdentifier string // lowercased and percent-decoded, for profile URLs
+	Slug             string // for company and school URLs
+	JobID            string // numeric job posting ID, for job URLs
+	Raw              string // the URL as it was parsed
+}
+
+// ParseLinkedInURL parses and classifies a LinkedIn URL. Locale (e.g. uk.linkedin.com) and mobile subdomains, query
+// parameters and trailing paths (e.g. /in/john-doe/details/experience/) are ignored.
+// It returns ErrNotLinkedInURL if the URL is not on linkedin.com, and classifies unknown pages as LinkedInOtherURL.
+func ParseLinkedInURL(rawURL string) (*LinkedInURL, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	// Pasted URLs often lack the scheme
+	if !strings.Contains(rawURL, "://") {
+		rawURL = "https://" + rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLinkedInURLInvalid, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %s (scheme must be http or https)", ErrLinkedInURLInvalid, rawURL)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "linkedin.com" && !strings.HasSuffix(host, ".linkedin.com") {
+		return nil, fmt.Errorf("%w: %s", ErrNotLinkedInURL, rawURL)
+	}
+
+	ret := &LinkedInURL{Kind: LinkedInOtherURL, Raw: rawURL}
+
+	var segments []string
+	for _, s := range strings.Split(u.EscapedPath(), "/") {
+		if s == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(s); err == nil {
+			s = unescaped
+		}
+		segments = append(segments, s)
+	}
+	if len(segments) < 2 {
+		return ret, nil
+	}
+
+	switch segments[0] {
+	case "in":
+		if id, ok := NormalizeLinkedInIdentifier(segments[1]); ok {
+			ret.Kind = LinkedInProfileURL
+			ret.PublicIdentifier = id
+		}
+	case "company":
+		if slug, ok := NormalizeLinkedInSlug(segments[1]); ok {
+			ret.Kind = LinkedInCompanyURL
+			ret.Slug = slug
+		}
+	case "school":
+		if slug, ok := NormalizeLinkedInSlug(segments[1]); ok {
+			ret.Kind = LinkedInSchoolURL
+			ret.Slug = slug
+		}
+	case "jobs":
+		if len(segments) >= 3 && segments[1] == "view" {
+			// Job URLs may be /jobs/view/<id> or /jobs/view/<title>-at-<company>-<id>
+			parts := strings.Split(segments[2], "-")
+			if id := parts[len(parts)-1]; linkedInJobIDRe.MatchString(id) {
+				ret.Kind = LinkedInJobURL
+				ret.JobID = id
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// NormalizeLinkedInIdentifier canonicalizes a profile public identifier: it strips surrounding slashes and a leading
+// '@', percent-decodes it and lowercases it. It returns false if the result is not a valid identifier.
+func NormalizeLinkedInIdentifier(identifier string) (string, bool) {
+	identifier = normalizeLinkedInName(identifier)
+	return identifier, linkedInIdentifierRe.MatchString(identifier)
+}
+
+// NormalizeLinkedInSlug canonicalizes a company or school slug, the same way as NormalizeLinkedInIdentifier.
+// It returns false if the result is not a valid slug.
+func NormalizeLinkedInSlug(slug string) (string, bool) {
+	slug = normalizeLinkedInName(slug)
+	return slug, linkedInSlugRe.MatchString(slug)
+}
+
+// normalizeLinkedInName strips surrounding slashes and a leading '@', then percent-decodes and lowercases, so that
+// encoded uppercase letters are lowercased too
+func normalizeLinkedInName(name string) string {
+	name = strings.Trim(strings.TrimSpace(name), "/")
+	name = strings.TrimPrefix(name, "@")
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	return strings.ToLower(name)
+}
+
+// CanonicalURL returns the canonical form of the URL, without locale subdomains, query parameters or trailing paths.
+// URLs of kind LinkedInOtherURL are returned as-is.
+func (l *LinkedInURL) CanonicalURL() string {
+	switch l.Kind {
+	case LinkedInProfileURL:
+		return linkedInProfileURL(url.PathEscape(l.PublicIdentifier))
+	case LinkedInCompanyURL:
+		return fmt.Sprintf("https://%s/company/%s/", linkedInCanonicalHost, url.PathEscape(l.Slug))
+	case LinkedInSchoolURL:
+		return fmt.Sprintf("https://%s/school/%s/", linkedInCanonicalHost, url.PathEscape(l.Slug))
+	case LinkedInJobURL:
+		return fmt.Sprintf("https://%s/jobs/view/%s/", linkedInCanonicalHost, l.JobID)
+	default:
+		return l.Raw
+	}
+}
@@ -0,0 +1,59 @@
+package types_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("ParseLinkedInURL", func() {
+	DescribeTable("classifies URLs",
+		func(rawURL string, kind types.LinkedInURLKind, id string, canonical string) {
+			u, err := types.ParseLinkedInURL(rawURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(u.Kind).To(Equal(kind))
+			Expect(u.PublicIdentifier + u.Slug + u.JobID).To(Equal(id))
+			Expect(u.CanonicalURL()).To(Equal(canonical))
+		},
+		Entry("profile", "https://www.linkedin.com/in/john-doe-123/", types.LinkedInProfileURL, "john-doe-123", "https://www.linkedin.com/in/john-doe-123/"),
+		Entry("locale subdomain and query", "https://uk.linkedin.com/in/John-Doe-123?originalSubdomain=uk", types.LinkedInProfileURL, "john-doe-123", "https://www.linkedin.com/in/john-doe-123/"),
+		Entry("trailing path", "https://www.linkedin.com/in/john-doe-123/details/experience/", types.LinkedInProfileURL, "john-doe-123", "https://www.linkedin.com/in/john-doe-123/"),
+		Entry("percent-encoded", "https://www.linkedin.com/in/j%C3%B6rg-m%C3%BCller/", types.LinkedInProfileURL, "jörg-müller", "https://www.linkedin.com/in/j%C3%B6rg-m%C3%BCller/"),
+		Entry("no scheme", "linkedin.com/in/jdoe", types.LinkedInProfileURL, "jdoe", "https://www.linkedin.com/in/jdoe/"),
+		Entry("company", "https://www.linkedin.com/company/Masa-Finance/about/", types.LinkedInCompanyURL, "masa-finance", "https://www.linkedin.com/company/masa-finance/"),
+		Entry("school", "https://www.linkedin.com/school/mit/", types.LinkedInSchoolURL, "mit", "https://www.linkedin.com/school/mit/"),
+		Entry("job", "https://www.linkedin.com/jobs/view/senior-engineer-at-acme-3912345678?refId=x", types.LinkedInJobURL, "3912345678", "https://www.linkedin.com/jobs/view/3912345678/"),
+		Entry("other", "https://www.linkedin.com/feed/", types.LinkedInOtherURL, "", "https://www.linkedin.com/feed/"),
+	)
+
+	It("should reject URLs outside linkedin.com", func() {
+		_, err := types.ParseLinkedInURL("https://linkedin.com.evil.com/in/jdoe")
+		Expect(errors.Is(err, types.ErrNotLinkedInURL)).To(BeTrue())
+
+		_, err = types.ParseLinkedInURL("ftp://www.linkedin.com/in/jdoe")
+		Expect(errors.Is(err, types.ErrLinkedInURLInvalid)).To(BeTrue())
+	})
+})
+
+var _ = Describe("NormalizeLinkedInSlug", func() {
+	It("should normalize slugs the same way as identifiers", func() {
+		for _, input := range []string{"/@Masa-Finance/", "%4Dasa-Finance", "masa%2DFINANCE", " @masa-finance "} {
+			slug, ok := types.NormalizeLinkedInSlug(input)
+			Expect(ok).To(BeTrue(), input)
+			Expect(slug).To(Equal("masa-finance"), input)
+
+			id, ok := types.NormalizeLinkedInIdentifier(input)
+			Expect(ok).To(BeTrue(), input)
+			Expect(id).To(Equal(slug), input)
+		}
+	})
+
+	It("should lowercase percent-encoded letters", func() {
+		slug, ok := types.NormalizeLinkedInSlug("%C3%96lwerk")
+		Expect(ok).To(BeTrue())
+		Expect(slug).To(Equal("ölwerk"))
+	})
+})
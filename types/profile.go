@@ -0,0 +1,234 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrProfileMismatch = errors.New("the profiles belong to different users")
+
+// Profile is the platform-agnostic representation of a user or author profile
+type Profile struct {
+	ID          string    `json:"id"` // Platform-native stable ID, see Key for a globally unique one
+	Platform    Platform  `json:"platform"`
+	Handle      string    `json:"handle"` // Username, screen name or public identifier, without any leading '@'
+	DisplayName string    `json:"display_name,omitempty"`
+	Bio         string    `json:"bio,omitempty"`
+	Location    string    `json:"location,omitempty"`
+	URL         string    `json:"url,omitempty"`     // URL of the profile page
+	Website     string    `json:"website,omitempty"` // Website linked from the profile
+	AvatarURL   string    `json:"avatar_url,omitempty"`
+	Followers   int64     `json:"followers"`
+	Following   int64     `json:"following"`
+	Verified    bool      `json:"verified"`
+	Private     bool      `json:"private"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Key returns an identifier that is unique across platforms, suitable for deduplication
+func (p Profile) Key() string {
+	return string(p.Platform) + ":" + p.ID
+}
+
+// Author returns the DocumentAuthor corresponding to the profile
+func (p Profile) Author() DocumentAuthor {
+	return DocumentAuthor{ID: p.ID, Username: p.Handle, DisplayName: p.DisplayName}
+}
+
+// Merge returns a copy of p with its empty fields filled in from fallback. Boolean flags are true if either
+// profile has them set. Profiles from different platforms are never merged, so p is returned unchanged.
+func (p Profile) Merge(fallback Profile) Profile {
+	if p.Platform != "" && fallback.Platform != "" && p.Platform != fallback.Platform {
+		return p
+	}
+
+	ret := p
+	ret.Platform = firstNonZero(p.Platform, fallback.Platform)
+	ret.ID = firstNonZero(p.ID, fallback.ID)
+	ret.Handle = firstNonZero(p.Handle, fallback.Handle)
+	ret.DisplayName = firstNonZero(p.DisplayName, fallback.DisplayName)
+	ret.Bio = firstNonZero(p.Bio, fallback.Bio)
+	ret.Location = firstNonZero(p.Location, fallback.Location)
+	ret.URL = firstNonZero(p.URL, fallback.URL)
+	ret.Website = firstNonZero(p.Website, fallback.Website)
+	ret.AvatarURL = firstNonZero(p.AvatarURL, fallback.AvatarURL)
+	ret.Followers = firstNonZero(p.Followers, fallback.Followers)
+	ret.Following = firstNonZero(p.Following, fallback.Following)
+	ret.Verified = p.Verified || fallback.Verified
+	ret.Private = p.Private || fallback.Private
+	if ret.CreatedAt.IsZero() {
+		ret.CreatedAt = fallback.CreatedAt
+	}
+	return ret
+}
+
+func firstNonZero[T comparable](values ...T) T {
+	var zero T
+	for _, v := range values {
+		if v != zero {
+			return v
+		}
+	}
+	return zero
+}
+
+// twitterProfileURL returns the URL of the profile page for a Twitter handle
+func twitterProfileURL(handle string) string {
+	if handle == "" {
+		return ""
+	}
+	return "https://x.com/" + handle
+}
+
+// ToProfile converts a Twitter profile returned by the Apify actor to a normalized Profile
+func (p *ProfileResultApify) ToProfile() Profile {
+	id := p.IDStr
+	if id == "" && p.ID != 0 {
+		id = strconv.FormatInt(p.ID, 10)
+	}
+
+	ret := Profile{
+		ID:          id,
+		Platform:    PlatformTwitter,
+		Handle:      p.ScreenName,
+		DisplayName: p.Name,
+		Bio:         p.Description,
+		Location:    p.Location,
+		URL:         twitterProfileURL(p.ScreenName),
+		AvatarURL:   firstNonZero(p.ProfileImageURLHTTPS, p.ProfileImageURL),
+		Followers:   int64(p.FollowersCount),
+		Following:   int64(p.FriendsCount),
+		Verified:    p.Verified,
+		Private:     p.Protected,
+	}
+
+	// The profile URL is a t.co link, the expanded one is in the entities
+	if p.Entities.URL != nil && len(p.Entities.URL.URLs) > 0 {
+		ret.Website = p.Entities.URL.URLs[0].ExpandedURL
+	}
+	if ret.Website == "" && p.URL != nil {
+		ret.Website = *p.URL
+	}
+
+	// Twitter API v1.1 timestamps, e.g. "Wed Oct 10 20:19:24 +0000 2018"
+	if createdAt, err := time.Parse(time.RubyDate, p.CreatedAt); err == nil {
+		ret.CreatedAt = createdAt.UTC()
+	}
+
+	return ret
+}
+
+// ToProfile converts a Twitter profile returned by the scraper to a normalized Profile
+func (p *ProfileResultScraper) ToProfile() Profile {
+	ret := Profile{
+		ID:          p.UserID,
+		Platform:    PlatformTwitter,
+		Handle:      p.Username,
+		DisplayName: p.Name,
+		Bio:         p.Biography,
+		Location:    p.Location,
+		URL:         firstNonZero(p.URL, twitterProfileURL(p.Username)),
+		Website:     p.Website,
+		AvatarURL:   p.Avatar,
+		Followers:   int64(p.FollowersCount),
+		Following:   int64(firstNonZero(p.FollowingCount, p.FriendsCount)),
+		Verified:    p.IsVerified || p.IsBlueVerified,
+		Private:     p.IsPrivate,
+	}
+	if p.Joined != nil {
+		ret.CreatedAt = p.Joined.UTC()
+	}
+	return ret
+}
+
+// MergeTwitterProfiles merges the Apify and scraper variants of the same Twitter profile into a single record.
+// Either argument can be nil. The precedence rules are:
+//   - Identity fields (ID, handle, display name, creation time) come from Apify, since they come straight from the
+//     Twitter API, falling back to the scraper.
+//   - Follower and following counts come from the scraper, since it reads the live profile page, falling back to Apify.
+//   - All other fields come from Apify, falling back to the scraper.
+//   - The verified and private flags are set if either variant has them set.
+//
+// It returns ErrProfileMismatch if both variants have an ID and the IDs differ, or if either lacks an ID and both
+// have a handle and the handles differ (ignoring case, as Twitter does).
+func MergeTwitterProfiles(apify *ProfileResultApify, scraper *ProfileResultScraper) (Profile, error) {
+	var fromApify, fromScraper Profile
+	if apify != nil {
+		fromApify = apify.ToProfile()
+	}
+	if scraper != nil {
+		fromScraper = scraper.ToProfile()
+	}
+
+	if fromApify.ID != "" && fromScraper.ID != "" {
+		if fromApify.ID != fromScraper.ID {
+			return Profile{}, fmt.Errorf("%w: ID %q != %q", ErrProfileMismatch, fromApify.ID, fromScraper.ID)
+		}
+	} else if fromApify.Handle != "" && fromScraper.Handle != "" && !strings.EqualFold(fromApify.Handle, fromScraper.Handle) {
+		return Profile{}, fmt.Errorf("%w: handle %q != %q", ErrProfileMismatch, fromApify.Handle, fromScraper.Handle)
+	}
+
+	ret := fromApify.Merge(fromScraper)
+	ret.Followers = firstNonZero(fromScraper.Followers, fromApify.Followers)
+	ret.Following = firstNonZero(fromScraper.Following, fromApify.Following)
+	return ret, nil
+}
+
+// linkedInProfileURL returns the URL of the profile page for a LinkedIn public identifier
+func linkedInProfileURL(publicIdentifier string) string {
+	if publicIdentifier == "" {
+		return ""
+	}
+	return "https://" + linkedInCanonicalHost + "/in/" + publicIdentifier + "/"
+}
+
+// linkedInProfileID returns the stable ID of a LinkedIn profile: its normalized public identifier, which unlike the
+// URN is present in both search results and full profiles
+func linkedInProfileID(publicIdentifier string) string {
+	return normalizeLinkedInName(publicIdentifier)
+}
+
+// ToProfile converts a LinkedIn profile search result to a normalized Profile. The headline is used as the bio.
+func (p *LinkedInProfileResult) ToProfile() Profile {
+	return Profile{
+		ID:          linkedInProfileID(p.PublicIdentifier),
+		Platform:    PlatformLinkedIn,
+		Handle:      p.PublicIdentifier,
+		DisplayName: p.FullName,
+		Bio:         p.Headline,
+		Location:    p.Location,
+		URL:         firstNonZero(p.ProfileURL, linkedInProfileURL(p.PublicIdentifier)),
+	}
+}
+
+// ToProfile converts a full LinkedIn profile to a normalized Profile. The summary is used as the bio, falling back
+// to the headline.
+func (p *LinkedInFullProfileResult) ToProfile() Profile {
+	return Profile{
+		ID:          linkedInProfileID(p.PublicIdentifier),
+		Platform:    PlatformLinkedIn,
+		Handle:      p.PublicIdentifier,
+		DisplayName: p.FullName,
+		Bio:         firstNonZero(p.Summary, p.Headline),
+		Location:    p.Location,
+		URL:         linkedInProfileURL(p.PublicIdentifier),
+		AvatarURL:   p.ProfilePictureURL,
+		Followers:   int64(p.FollowerCount),
+	}
+}
+
+// ToProfile converts a Reddit user to a normalized Profile. Reddit does not expose follower counts.
+func (u *RedditUser) ToProfile() Profile {
+	return Profile{
+		ID:        u.ID,
+		Platform:  PlatformReddit,
+		Handle:    u.Username,
+		Bio:       u.Description,
+		URL:       u.URL,
+		AvatarURL: u.UserIcon,
+		CreatedAt: u.CreatedAt.UTC(),
+	}
+}
@@ -0,0 +1,116 @@
+package types_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("Profile", func() {
+	joined := time.Date(2018, 10, 10, 20, 19, 24, 0, time.UTC)
+	website := "https://t.co/abc"
+
+	apify := &types.ProfileResultApify{
+		ID:                   1234,
+		ScreenName:           "masa_finance",
+		Name:                 "Masa",
+		Description:          "Apify bio",
+		URL:                  &website,
+		Entities:             types.ProfileEntities{URL: &types.URLEntities{URLs: []types.URLEntity{{ExpandedURL: "https://masa.ai"}}}},
+		FollowersCount:       100,
+		FriendsCount:         10,
+		CreatedAt:            "Wed Oct 10 20:19:24 +0000 2018",
+		ProfileImageURLHTTPS: "https://pbs.twimg.com/avatar.jpg",
+	}
+	scraper := &types.ProfileResultScraper{
+		UserID:         "1234",
+		Username:       "masa_finance",
+		Biography:      "Scraper bio",
+		Location:       "Internet",
+		FollowersCount: 150,
+		IsBlueVerified: true,
+	}
+
+	It("should convert an Apify Twitter profile", func() {
+		p := apify.ToProfile()
+		Expect(p.Key()).To(Equal("twitter:1234"))
+		Expect(p.Handle).To(Equal("masa_finance"))
+		Expect(p.URL).To(Equal("https://x.com/masa_finance"))
+		Expect(p.Website).To(Equal("https://masa.ai"))
+		Expect(p.Following).To(Equal(int64(10)))
+		Expect(p.CreatedAt).To(Equal(joined))
+	})
+
+	It("should convert a scraper Twitter profile", func() {
+		s := *scraper
+		s.Joined = &joined
+		p := s.ToProfile()
+		Expect(p.ID).To(Equal("1234"))
+		Expect(p.Bio).To(Equal("Scraper bio"))
+		Expect(p.Verified).To(BeTrue())
+		Expect(p.CreatedAt).To(Equal(joined))
+	})
+
+	It("should merge the Twitter profile variants", func() {
+		p, err := types.MergeTwitterProfiles(apify, scraper)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(p.ID).To(Equal("1234"))
+		Expect(p.DisplayName).To(Equal("Masa"))
+		Expect(p.Bio).To(Equal("Apify bio"))
+		Expect(p.Location).To(Equal("Internet"))
+		Expect(p.Followers).To(Equal(int64(150)))
+		Expect(p.Following).To(Equal(int64(10)))
+		Expect(p.Verified).To(BeTrue())
+		Expect(p.CreatedAt).To(Equal(joined))
+
+		Expect(types.MergeTwitterProfiles(nil, scraper)).To(Equal(scraper.ToProfile()))
+		Expect(types.MergeTwitterProfiles(apify, nil)).To(Equal(apify.ToProfile()))
+	})
+
+	It("should not merge the Twitter profiles of different users", func() {
+		other := *scraper
+		other.UserID = "5678"
+		_, err := types.MergeTwitterProfiles(apify, &other)
+		Expect(errors.Is(err, types.ErrProfileMismatch)).To(BeTrue())
+
+		other = *scraper
+		other.UserID = ""
+		other.Username = "someone_else"
+		_, err = types.MergeTwitterProfiles(apify, &other)
+		Expect(errors.Is(err, types.ErrProfileMismatch)).To(BeTrue())
+
+		other.Username = "MASA_Finance"
+		_, err = types.MergeTwitterProfiles(apify, &other)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("should not merge profiles from different platforms", func() {
+		twitter := types.Profile{Platform: types.PlatformTwitter, ID: "1"}
+		reddit := types.Profile{Platform: types.PlatformReddit, ID: "2", Bio: "bio"}
+		Expect(twitter.Merge(reddit)).To(Equal(twitter))
+	})
+
+	It("should convert LinkedIn profiles", func() {
+		p := (&types.LinkedInProfileResult{PublicIdentifier: "JDoe", URN: "urn:li:member:1", FullName: "John Doe", Headline: "Engineer"}).ToProfile()
+		Expect(p.Key()).To(Equal("linkedin:jdoe"))
+		Expect(p.Bio).To(Equal("Engineer"))
+		Expect(p.URL).To(Equal("https://www.linkedin.com/in/JDoe/"))
+
+		full := (&types.LinkedInFullProfileResult{PublicIdentifier: "jdoe", Headline: "Engineer", Summary: "Summary", ProfilePictureURL: "https://media.licdn.com/a.jpg"}).ToProfile()
+		Expect(full.Key()).To(Equal(p.Key()))
+		Expect(full.Bio).To(Equal("Summary"))
+		Expect(full.AvatarURL).To(Equal("https://media.licdn.com/a.jpg"))
+	})
+
+	It("should convert a Reddit user", func() {
+		p := (&types.RedditUser{ID: "t2_abc", Username: "spez", Description: "bio", UserIcon: "https://i.redd.it/a.png", CreatedAt: joined}).ToProfile()
+		Expect(p.Key()).To(Equal("reddit:t2_abc"))
+		Expect(p.Author()).To(Equal(types.DocumentAuthor{ID: "t2_abc", Username: "spez"}))
+		Expect(p.AvatarURL).To(Equal("https://i.redd.it/a.png"))
+		Expect(p.CreatedAt).To(Equal(joined))
+	})
+})
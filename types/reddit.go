@@ -39,12 +39,59 @@ var AllRedditSortTypes = util.NewSet(
 	RedditSortComments,
 )
 
+// RedditTime is the period that posts sorted by RedditSortTop are ranked over
+type RedditTime string
+
+const (
+	RedditTimeHour  RedditTime = "hour"
+	RedditTimeDay   RedditTime = "day"
+	RedditTimeWeek  RedditTime = "week"
+	RedditTimeMonth RedditTime = "month"
+	RedditTimeYear  RedditTime = "year"
+	RedditTimeAll   RedditTime = "all"
+)
+
+var AllRedditTimes = util.NewSet(
+	RedditTimeHour,
+	RedditTimeDay,
+	RedditTimeWeek,
+	RedditTimeMonth,
+	RedditTimeYear,
+	RedditTimeAll,
+)
+
 // RedditStartURL represents a single start URL for the Apify Reddit scraper.
 type RedditStartURL struct {
 	URL    string `json:"url"`
 	Method string `json:"method"`
 }
 
+// RedditScraperRequest represents the input for the Apify Reddit scraper.
+// see https://apify.com/trudax/reddit-scraper/input-schema
+type RedditScraperRequest struct {
+	StartUrls           []RedditStartURL `json:"startUrls,omitempty"`
+	Searches            []string         `json:"searches,omitempty"`
+	SearchPosts         bool             `json:"searchPosts"`
+	SearchComments      bool             `json:"searchComments"`
+	SearchCommunities   bool             `json:"searchCommunities"`
+	SearchUsers         bool             `json:"searchUsers"`
+	SkipComments        bool             `json:"skipComments"`
+	SkipUserPosts       bool             `json:"skipUserPosts"`
+	SkipCommunity       bool             `json:"skipCommunity"`
+	Sort                RedditSortType   `json:"sort,omitempty"`
+	Time                RedditTime       `json:"time,omitempty"`
+	PostDateLimit       *time.Time       `json:"postDateLimit,omitempty"`
+	IncludeNSFW         bool             `json:"includeNSFW"`
+	MaxItems            uint             `json:"maxItems"`
+	MaxPostCount        uint             `json:"maxPostCount"`
+	MaxComments         uint             `json:"maxComments"`
+	MaxCommunitiesCount uint             `json:"maxCommunitiesCount"`
+	MaxUserCount        uint             `json:"maxUserCount"`
+
+	// MaxResults is not an actor input, it is the number of results per page the worker reads from the dataset
+	MaxResults uint `json:"-"`
+}
+
 type RedditItemType string
 
 const (
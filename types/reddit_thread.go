@@ -0,0 +1,160 @@
+package types
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	redditCommentPrefix = "t1_"
+	redditPostPrefix    = "t3_"
+)
+
+var redditFullnameRe = regexp.MustCompile(`^t[0-9]_`)
+
+// RedditThread is a post together with the tree of comments replying to it
+type RedditThread struct {
+	PostID       string               `json:"post_id"`        // Fullname of the post, e.g. "t3_abc123". Empty if it could not be determined.
+	Post         *RedditPost          `json:"post,omitempty"` // nil if the post itself was not scraped
+	Comments     []*RedditCommentNode `json:"comments"`       // Top-level comments, including orphans
+	CommentCount int                  `json:"comment_count"`  // Total number of comments in the thread, at any depth
+	MaxDepth     int                  `json:"max_depth"`      // Depth of the most deeply nested comment, 0 if there are none
+}
+
+// RedditCommentNode is a comment in a RedditThread, together with its replies
+type RedditCommentNode struct {
+	Comment     *RedditComment       `json:"comment"`
+	Replies     []*RedditCommentNode `json:"replies"`
+	Depth       int                  `json:"depth"`       // 1 for top-level comments
+	Descendants int                  `json:"descendants"` // Number of replies at any depth below this comment
+	Orphan      bool                 `json:"orphan"`      // The parent comment was not scraped, so this comment is attached at the top level
+
+	parent *RedditCommentNode
+}
+
+// Walk calls fn for every comment in the thread, depth-first and in input order.
+// If fn returns false the replies of that comment are skipped.
+func (t *RedditThread) Walk(fn func(*RedditCommentNode) bool) {
+	for _, c := range t.Comments {
+		c.Walk(fn)
+	}
+}
+
+// Walk calls fn for the comment and then for its replies, depth-first.
+// If fn returns false the replies are skipped.
+func (n *RedditCommentNode) Walk(fn func(*RedditCommentNode) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, r := range n.Replies {
+		r.Walk(fn)
+	}
+}
+
+// redditFullname adds the type prefix to id if it does not have one already
+func redditFullname(prefix, id string) string {
+	if id == "" || redditFullnameRe.MatchString(id) {
+		return id
+	}
+	return prefix + id
+}
+
+// BuildRedditThreads reconstructs the discussion threads in a flat list of Reddit items, as returned by the scraper.
+// Threads of scraped posts come first, in input order, followed by the threads of posts that were not scraped.
+// Comments keep their input order among siblings. Comments whose parent comment is missing are attached at the top level of their
+// thread and marked as orphans, and comments whose post is missing are grouped into a thread with a nil Post.
+// Users, communities and duplicate posts or comments are ignored.
+func BuildRedditThreads(items []RedditItem) []*RedditThread {
+	var threads []*RedditThread
+	threadsByID := make(map[string]*RedditThread)
+
+	getThread := func(postID string) *RedditThread {
+		if t, ok := threadsByID[postID]; ok {
+			return t
+		}
+		t := &RedditThread{PostID: postID, Comments: []*RedditCommentNode{}}
+		threadsByID[postID] = t
+		threads = append(threads, t)
+		return t
+	}
+
+	var nodes []*RedditCommentNode
+	nodesByID := make(map[string]*RedditCommentNode)
+
+	for _, item := range items {
+		switch {
+		case item.Post != nil:
+			t := getThread(redditFullname(redditPostPrefix, item.Post.ID))
+			if t.Post == nil {
+				t.Post = item.Post
+			}
+		case item.Comment != nil:
+			id := redditFullname(redditCommentPrefix, item.Comment.ID)
+			if _, ok := nodesByID[id]; ok {
+				continue
+			}
+			n := &RedditCommentNode{Comment: item.Comment, Replies: []*RedditCommentNode{}}
+			nodesByID[id] = n
+			nodes = append(nodes, n)
+		}
+	}
+
+	// Link every comment to its parent, then attach it to its thread or parent. Links are checked against the
+	// ancestors of the parent, so malformed input cannot create a cycle.
+	for _, n := range nodes {
+		parentID := n.Comment.ParentID
+		if !strings.HasPrefix(parentID, redditPostPrefix) {
+			if p, ok := nodesByID[redditFullname(redditCommentPrefix, parentID)]; ok && !p.hasAncestor(n) {
+				n.parent = p
+			}
+		}
+	}
+
+	for _, n := range nodes {
+		if n.parent != nil {
+			n.parent.Replies = append(n.parent.Replies, n)
+			continue
+		}
+
+		postID := n.Comment.ParentID
+		if !strings.HasPrefix(postID, redditPostPrefix) {
+			n.Orphan = true
+			postID = redditPostIDFromURL(n.Comment.URL)
+		}
+
+		t := getThread(postID)
+		t.Comments = append(t.Comments, n)
+	}
+
+	for _, t := range threads {
+		for _, c := range t.Comments {
+			c.computeStats(1)
+			t.CommentCount += 1 + c.Descendants
+		}
+		t.Walk(func(n *RedditCommentNode) bool {
+			t.MaxDepth = max(t.MaxDepth, n.Depth)
+			return true
+		})
+	}
+
+	return threads
+}
+
+// hasAncestor returns true if a is n or one of its ancestors
+func (n *RedditCommentNode) hasAncestor(a *RedditCommentNode) bool {
+	for p := n; p != nil; p = p.parent {
+		if p == a {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *RedditCommentNode) computeStats(depth int) {
+	n.Depth = depth
+	n.Descendants = 0
+	for _, r := range n.Replies {
+		r.computeStats(depth + 1)
+		n.Descendants += 1 + r.Descendants
+	}
+}
@@ -0,0 +1,100 @@
+package types_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+func redditPost(id string) types.RedditItem {
+	return types.RedditItem{Post: &types.RedditPost{ID: id}}
+}
+
+func redditComment(id, parentID, url string) types.RedditItem {
+	return types.RedditItem{Comment: &types.RedditComment{ID: id, ParentID: parentID, URL: url}}
+}
+
+var _ = Describe("BuildRedditThreads", func() {
+	It("should nest comments under their post and parents", func() {
+		threads := types.BuildRedditThreads([]types.RedditItem{
+			redditPost("t3_a"),
+			redditComment("t1_c1", "t3_a", ""),
+			redditComment("t1_c2", "t1_c1", ""),
+			redditComment("t1_c3", "t1_c2", ""),
+			redditComment("t1_c4", "t3_a", ""),
+			{User: &types.RedditUser{ID: "t2_u"}},
+		})
+
+		Expect(threads).To(HaveLen(1))
+		t := threads[0]
+		Expect(t.PostID).To(Equal("t3_a"))
+		Expect(t.Post).NotTo(BeNil())
+		Expect(t.CommentCount).To(Equal(4))
+		Expect(t.MaxDepth).To(Equal(3))
+
+		Expect(t.Comments).To(HaveLen(2))
+		c1 := t.Comments[0]
+		Expect(c1.Comment.ID).To(Equal("t1_c1"))
+		Expect(c1.Depth).To(Equal(1))
+		Expect(c1.Descendants).To(Equal(2))
+		Expect(c1.Replies[0].Replies[0].Comment.ID).To(Equal("t1_c3"))
+		Expect(c1.Replies[0].Replies[0].Depth).To(Equal(3))
+		Expect(t.Comments[1].Descendants).To(Equal(0))
+	})
+
+	It("should accept comments that come before their parent", func() {
+		threads := types.BuildRedditThreads([]types.RedditItem{
+			redditComment("t1_c2", "t1_c1", ""),
+			redditComment("t1_c1", "t3_a", ""),
+			redditPost("t3_a"),
+		})
+		Expect(threads).To(HaveLen(1))
+		Expect(threads[0].Post).NotTo(BeNil())
+		Expect(threads[0].Comments).To(HaveLen(1))
+		Expect(threads[0].Comments[0].Replies[0].Comment.ID).To(Equal("t1_c2"))
+	})
+
+	It("should attach orphans at the top level of their thread", func() {
+		threads := types.BuildRedditThreads([]types.RedditItem{
+			redditPost("t3_a"),
+			redditComment("t1_c2", "t1_missing", "https://www.reddit.com/r/golang/comments/a/title/c2/"),
+			redditComment("t1_c3", "t1_c2", ""),
+		})
+		Expect(threads).To(HaveLen(1))
+		orphan := threads[0].Comments[0]
+		Expect(orphan.Orphan).To(BeTrue())
+		Expect(orphan.Descendants).To(Equal(1))
+		Expect(orphan.Replies[0].Orphan).To(BeFalse())
+	})
+
+	It("should group comments whose post was not scraped", func() {
+		threads := types.BuildRedditThreads([]types.RedditItem{
+			redditComment("t1_c1", "t3_b", ""),
+			redditComment("t1_c2", "t1_unknown", ""),
+		})
+		Expect(threads).To(HaveLen(2))
+		Expect(threads[0].PostID).To(Equal("t3_b"))
+		Expect(threads[0].Post).To(BeNil())
+		Expect(threads[1].PostID).To(BeEmpty())
+		Expect(threads[1].Comments[0].Orphan).To(BeTrue())
+	})
+
+	It("should ignore duplicates and break cycles", func() {
+		threads := types.BuildRedditThreads([]types.RedditItem{
+			redditComment("t1_c1", "t1_c2", ""),
+			redditComment("t1_c2", "t1_c1", ""),
+			redditComment("t1_c1", "t3_a", ""),
+			redditComment("t1_c3", "t1_c3", ""),
+		})
+		Expect(threads).To(HaveLen(1))
+		Expect(threads[0].CommentCount).To(Equal(3))
+
+		var ids []string
+		threads[0].Walk(func(n *types.RedditCommentNode) bool {
+			ids = append(ids, n.Comment.ID)
+			return true
+		})
+		Expect(ids).To(ConsistOf("t1_c1", "t1_c2", "t1_c3"))
+	})
+})
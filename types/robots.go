@@ -0,0 +1,267 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RobotsTxtMaxSize is the number of bytes of a robots.txt file that are parsed, as recommended by RFC 9309
+const RobotsTxtMaxSize = 500 * 1024
+
+// RobotsTxt is a parsed robots.txt file, see RFC 9309. The zero value and nil allow everything.
+type RobotsTxt struct {
+	Sitemaps []string // The sitemap URLs listed in the file, which apply to all user agents
+
+	groups      []robotsGroup
+	disallowAll bool
+}
+
+type robotsGroup struct {
+	agents     []string // lowercased product tokens, or "*"
+	rules      []robotsRule
+	crawlDelay time.Duration
+	hasDelay   bool
+}
+
+type robotsRule struct {
+	allow   bool
+	pattern string
+	re      *regexp.Regexp
+}
+
+// ParseRobotsTxt parses the content of a robots.txt file. Parsing is lenient: invalid lines are ignored.
+func ParseRobotsTxt(content []byte) *RobotsTxt {
+	if len(content) > RobotsTxtMaxSize {
+		content = content[:RobotsTxtMaxSize]
+	}
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+
+	ret := &RobotsTxt{}
+	var current *robotsGroup
+	inAgents := false
+
+	for _, line := range strings.FieldsFunc(string(content), func(r rune) bool { return r == '\n' || r == '\r' }) {
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "user-agent", "useragent", "user agent":
+			if !inAgents {
+				ret.groups = append(ret.groups, robotsGroup{})
+				current = &ret.groups[len(ret.groups)-1]
+				inAgents = true
+			}
+			current.agents = append(current.agents, robotsProductToken(value))
+		case "allow", "disallow":
+			inAgents = false
+			// An empty Disallow allows everything, which is the default
+			if current == nil || value == "" {
+				continue
+			}
+			current.rules = append(current.rules, robotsRule{
+				allow:   key == "allow",
+				pattern: robotsNormalize(value),
+				re:      robotsPatternRegexp(value),
+			})
+		case "crawl-delay":
+			inAgents = false
+			if current == nil {
+				continue
+			}
+			if seconds, err := strconv.ParseFloat(value, 64); err == nil && seconds >= 0 {
+				current.crawlDelay = time.Duration(seconds * float64(time.Second))
+				current.hasDelay = true
+			}
+		case "sitemap":
+			if value != "" {
+				ret.Sitemaps = append(ret.Sitemaps, value)
+			}
+		}
+	}
+
+	return ret
+}
+
+// ParseRobotsTxtResponse returns the robots.txt rules for a fetch that ended with the given HTTP status code, after
+// following redirects. As required by RFC 9309, an unavailable file (4xx, or 3xx when the redirect limit was reached)
+// allows everything and an unreachable one (5xx, or any other status) disallows everything.
+func ParseRobotsTxtResponse(statusCode int, body []byte) *RobotsTxt {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return ParseRobotsTxt(body)
+	case statusCode >= 300 && statusCode < 500:
+		return &RobotsTxt{}
+	default:
+		return &RobotsTxt{disallowAll: true}
+	}
+}
+
+// robotsProductToken extracts the product token of a user agent, e.g. "masabot" for "MasaBot/1.0 (+https://masa.ai)"
+func robotsProductToken(userAgent string) string {
+	token, _, _ := strings.Cut(strings.TrimSpace(userAgent), "/")
+	token, _, _ = strings.Cut(token, " ")
+	return strings.ToLower(token)
+}
+
+// robotsPatternRegexp compiles a path pattern, where '*' matches any sequence of characters and a trailing '$'
+// anchors the pattern at the end of the path
+func robotsPatternRegexp(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i, part := range strings.Split(robotsNormalize(pattern), "*") {
+		if i > 0 {
+			sb.WriteString(".*")
+		}
+		sb.WriteString(regexp.QuoteMeta(part))
+	}
+	if anchored {
+		sb.WriteString("$")
+	}
+	return regexp.MustCompile(sb.String())
+}
+
+// robotsNormalize brings a pattern or an escaped URL path to the canonical form used for matching (RFC 9309, 2.2.2):
+// percent-encoded unreserved characters are decoded, other percent-encodings use uppercase hex digits, and
+// non-ASCII and control characters are percent-encoded
+func robotsNormalize(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]) {
+			decoded := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if isRobotsUnreserved(decoded) {
+				sb.WriteByte(decoded)
+			} else {
+				fmt.Fprintf(&sb, "%%%02X", decoded)
+			}
+			i += 2
+			continue
+		}
+		if c >= 0x80 || c <= 0x20 || c == 0x7f {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		} else {
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+// isRobotsUnreserved reports whether c is an unreserved character of RFC 3986
+func isRobotsUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+// groupsFor returns the groups that apply to the user agent: the groups naming its product token or, if there are
+// none, the groups for "*"
+func (r *RobotsTxt) groupsFor(userAgent string) []*robotsGroup {
+	token := robotsProductToken(userAgent)
+
+	var matching, wildcard []*robotsGroup
+	for i := range r.groups {
+		g := &r.groups[i]
+		for _, agent := range g.agents {
+			if agent == token && token != "" {
+				matching = append(matching, g)
+				break
+			}
+			if agent == "*" {
+				wildcard = append(wildcard, g)
+				break
+			}
+		}
+	}
+
+	if len(matching) > 0 {
+		return matching
+	}
+	return wildcard
+}
+
+// Allowed returns true if the user agent may crawl rawURL. The most specific (longest) matching rule wins, and Allow
+// wins over Disallow when they are equally specific. The robots.txt file itself is always allowed.
+func (r *RobotsTxt) Allowed(userAgent, rawURL string) bool {
+	if r == nil {
+		return true
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	path := u.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	if path == "/robots.txt" {
+		return true
+	}
+	if r.disallowAll {
+		return false
+	}
+	if u.RawQuery != "" {
+		path += "?" + u.RawQuery
+	}
+	path = robotsNormalize(path)
+
+	allowed, matchLen := true, -1
+	for _, g := range r.groupsFor(userAgent) {
+		for _, rule := range g.rules {
+			if !rule.re.MatchString(path) {
+				continue
+			}
+			if n := len(rule.pattern); n > matchLen || (n == matchLen && rule.allow) {
+				allowed, matchLen = rule.allow, n
+			}
+		}
+	}
+	return allowed
+}
+
+// CrawlDelay returns the delay between requests that applies to the user agent, or 0 if there is none
+func (r *RobotsTxt) CrawlDelay(userAgent string) time.Duration {
+	if r == nil {
+		return 0
+	}
+	for _, g := range r.groupsFor(userAgent) {
+		if g.hasDelay {
+			return g.crawlDelay
+		}
+	}
+	return 0
+}
+
+// RobotsAllowed returns true if the crawl may fetch rawURL: always if the request does not respect robots.txt,
+// otherwise if the robots.txt of the URL's host allows it
+func (r WebScraperRequest) RobotsAllowed(robots *RobotsTxt, userAgent, rawURL string) bool {
+	return !r.RespectRobotsTxtFile || robots.Allowed(userAgent, rawURL)
+}
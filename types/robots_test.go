@@ -0,0 +1,126 @@
+package types_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+const robotsTxt = "\ufeff" + `# Example robots.txt
+User-agent: *
+Disallow: /private/
+Allow: /private/public-page
+Disallow: /*.pdf$
+Disallow: /search?
+Crawl-delay: 2.5
+
+User-agent: MasaBot
+User-agent: OtherBot
+Disallow: /
+Allow: /blog/
+Allow: /$
+
+user-agent: masabot
+crawl-delay: 10
+
+User-agent: EmptyBot
+Disallow:
+
+Sitemap: https://example.com/sitemap.xml
+Sitemap: https://example.com/news-sitemap.xml.gz
+`
+
+var _ = Describe("RobotsTxt", func() {
+	robots := types.ParseRobotsTxt([]byte(robotsTxt))
+
+	DescribeTable("allowed URLs",
+		func(userAgent, rawURL string, allowed bool) {
+			Expect(robots.Allowed(userAgent, rawURL)).To(Equal(allowed))
+		},
+		Entry("wildcard group, unmatched path", "SomeBot/1.0", "https://example.com/about", true),
+		Entry("wildcard group, disallowed directory", "SomeBot/1.0", "https://example.com/private/data", false),
+		Entry("longest match wins", "SomeBot/1.0", "https://example.com/private/public-page", true),
+		Entry("wildcard with end anchor", "SomeBot/1.0", "https://example.com/docs/manual.pdf", false),
+		Entry("end anchor does not match longer paths", "SomeBot/1.0", "https://example.com/docs/manual.pdf.html", true),
+		Entry("query strings are matched", "SomeBot/1.0", "https://example.com/search?q=go", false),
+		Entry("named group replaces the wildcard group", "MasaBot/1.0 (+https://masa.ai)", "https://example.com/about", false),
+		Entry("named group allow", "MasaBot/1.0", "https://example.com/blog/post", true),
+		Entry("named group matching is case insensitive", "masabot", "https://example.com/blog/post", true),
+		Entry("named group end anchor", "MasaBot", "https://example.com/", true),
+		Entry("group with several user agents", "OtherBot", "https://example.com/about", false),
+		Entry("empty disallow allows everything", "EmptyBot", "https://example.com/private/data", true),
+		Entry("robots.txt is always allowed", "MasaBot", "https://example.com/robots.txt", true),
+	)
+
+	It("should resolve equally specific rules in favor of allow", func() {
+		robots := types.ParseRobotsTxt([]byte("User-agent: *\nDisallow: /page\nAllow: /page\n"))
+		Expect(robots.Allowed("bot", "https://example.com/page")).To(BeTrue())
+	})
+
+	It("should match percent-encoded paths", func() {
+		robots := types.ParseRobotsTxt([]byte("User-agent: *\nDisallow: /café\n"))
+		Expect(robots.Allowed("bot", "https://example.com/caf%C3%A9/menu")).To(BeFalse())
+	})
+
+	DescribeTable("percent-encoding normalization",
+		func(rule, rawURL string) {
+			robots := types.ParseRobotsTxt([]byte("User-agent: *\nDisallow: " + rule + "\n"))
+			Expect(robots.Allowed("bot", rawURL)).To(BeFalse())
+		},
+		Entry("encoded unreserved character in the rule", "/%7Ejoe", "https://a.com/~joe/"),
+		Entry("encoded unreserved character in the URL", "/~joe", "https://a.com/%7ejoe/"),
+		Entry("lowercase hex digits", "/a%2fb", "https://a.com/a%2Fb/c"),
+		Entry("encoded reserved character in both", "/%2A", "https://a.com/%2a"),
+	)
+
+	It("should not decode reserved characters", func() {
+		robots := types.ParseRobotsTxt([]byte("User-agent: *\nDisallow: /a%2Fb\n"))
+		Expect(robots.Allowed("bot", "https://a.com/a/b")).To(BeTrue())
+	})
+
+	It("should return the crawl delay of the matching groups", func() {
+		Expect(robots.CrawlDelay("SomeBot")).To(Equal(2500 * time.Millisecond))
+		Expect(robots.CrawlDelay("MasaBot")).To(Equal(10 * time.Second))
+		Expect(robots.CrawlDelay("OtherBot")).To(BeZero())
+	})
+
+	It("should collect the sitemaps", func() {
+		Expect(robots.Sitemaps).To(Equal([]string{
+			"https://example.com/sitemap.xml",
+			"https://example.com/news-sitemap.xml.gz",
+		}))
+	})
+
+	It("should ignore rules outside of groups and invalid lines", func() {
+		robots := types.ParseRobotsTxt([]byte("Disallow: /\nthis is not a rule\nUser-agent: *\nCrawl-delay: soon\n"))
+		Expect(robots.Allowed("bot", "https://example.com/")).To(BeTrue())
+		Expect(robots.CrawlDelay("bot")).To(BeZero())
+	})
+
+	It("should allow everything when nil", func() {
+		var robots *types.RobotsTxt
+		Expect(robots.Allowed("bot", "https://example.com/private")).To(BeTrue())
+		Expect(robots.CrawlDelay("bot")).To(BeZero())
+	})
+
+	DescribeTable("HTTP status handling",
+		func(statusCode int, allowed bool) {
+			robots := types.ParseRobotsTxtResponse(statusCode, []byte("User-agent: *\nDisallow: /private/\n"))
+			Expect(robots.Allowed("bot", "https://example.com/page")).To(Equal(allowed))
+			Expect(robots.Allowed("bot", "https://example.com/robots.txt")).To(BeTrue())
+		},
+		Entry("success parses the body", 200, true),
+		Entry("not found allows everything", 404, true),
+		Entry("too many redirects allows everything", 301, true),
+		Entry("server error disallows everything", 503, false),
+	)
+
+	It("should only apply to requests that respect robots.txt", func() {
+		robots := types.ParseRobotsTxt([]byte("User-agent: *\nDisallow: /\n"))
+		Expect(types.WebScraperRequest{}.RobotsAllowed(robots, "bot", "https://example.com/")).To(BeTrue())
+		Expect(types.WebScraperRequest{RespectRobotsTxtFile: true}.RobotsAllowed(robots, "bot", "https://example.com/")).To(BeFalse())
+	})
+})
@@ -0,0 +1,289 @@
+package types
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSitemapInvalid  = errors.New("invalid sitemap")
+	ErrSitemapTooLarge = errors.New("sitemap exceeds the maximum size")
+)
+
+const (
+	// SitemapMaxSize is the maximum uncompressed size of a sitemap, see https://www.sitemaps.org/protocol.html
+	SitemapMaxSize = 50 * 1024 * 1024
+	// SitemapDefaultMaxDepth is the default number of nested sitemap indexes that are followed. The protocol does not
+	// allow nested indexes, but some sites use them.
+	SitemapDefaultMaxDepth = 2
+	// SitemapDefaultMaxSitemaps is the default number of sitemaps fetched by ExpandSitemaps, which bounds the download
+	// to SitemapDefaultMaxSitemaps * SitemapMaxSize bytes
+	SitemapDefaultMaxSitemaps = 100
+)
+
+// SitemapURL is a page listed in a sitemap
+type SitemapURL struct {
+	Loc        string    `json:"loc"`
+	LastMod    time.Time `json:"lastmod,omitzero"` // zero if missing or invalid
+	ChangeFreq string    `json:"changefreq,omitempty"`
+	Priority   float64   `json:"priority,omitempty"`
+}
+
+// SitemapRef is a sitemap listed in a sitemap index
+type SitemapRef struct {
+	Loc     string    `json:"loc"`
+	LastMod time.Time `json:"lastmod,omitzero"`
+}
+
+// Sitemap is a parsed sitemap (a <urlset>) or sitemap index (a <sitemapindex>)
+type Sitemap struct {
+	URLs     []SitemapURL
+	Sitemaps []SitemapRef
+	Index    bool
+}
+
+type sitemapXML struct {
+	XMLName xml.Name
+	URLs    []struct {
+		Loc        string `xml:"loc"`
+		LastMod    string `xml:"lastmod"`
+		ChangeFreq string `xml:"changefreq"`
+		Priority   string `xml:"priority"`
+	} `xml:"url"`
+	Sitemaps []struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod"`
+	} `xml:"sitemap"`
+}
+
+// ParseSitemap parses a sitemap or sitemap index, which may be gzip-compressed. Entries without a location are
+// skipped and invalid dates or priorities are ignored.
+func ParseSitemap(r io.Reader) (*Sitemap, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrSitemapInvalid, err)
+		}
+		defer gz.Close()
+		r = gz
+	} else {
+		r = br
+	}
+
+	var doc sitemapXML
+	if err := xml.NewDecoder(&sitemapLimitReader{r: r, remaining: SitemapMaxSize}).Decode(&doc); err != nil {
+		if errors.Is(err, ErrSitemapTooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrSitemapInvalid, err)
+	}
+
+	ret := &Sitemap{}
+	switch doc.XMLName.Local {
+	case "urlset":
+		for _, u := range doc.URLs {
+			if loc := strings.TrimSpace(u.Loc); loc != "" {
+				priority, _ := strconv.ParseFloat(strings.TrimSpace(u.Priority), 64)
+				ret.URLs = append(ret.URLs, SitemapURL{
+					Loc:        loc,
+					LastMod:    parseW3CDatetime(u.LastMod),
+					ChangeFreq: strings.ToLower(strings.TrimSpace(u.ChangeFreq)),
+					Priority:   priority,
+				})
+			}
+		}
+	case "sitemapindex":
+		ret.Index = true
+		for _, s := range doc.Sitemaps {
+			if loc := strings.TrimSpace(s.Loc); loc != "" {
+				ret.Sitemaps = append(ret.Sitemaps, SitemapRef{Loc: loc, LastMod: parseW3CDatetime(s.LastMod)})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w: unexpected root element <%s>", ErrSitemapInvalid, doc.XMLName.Local)
+	}
+
+	return ret, nil
+}
+
+// sitemapLimitReader fails with ErrSitemapTooLarge instead of silently truncating, to guard against gzip bombs
+type sitemapLimitReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+func (l *sitemapLimitReader) Read(p []byte) (int, error) {
+	if l.remaining <= 0 {
+		return 0, ErrSitemapTooLarge
+	}
+	if int64(len(p)) > l.remaining {
+		p = p[:l.remaining]
+	}
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// parseW3CDatetime parses the W3C Datetime formats used in sitemaps (e.g. "2024-01-02", "2024-01-02T15:04:05+00:00"),
+// returning the zero time if the value is invalid
+func parseW3CDatetime(s string) time.Time {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04Z07:00", "2006-01-02", "2006-01", "2006"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// SitemapFetcher returns the content of the sitemap at sitemapURL. Workers implement it with their HTTP client.
+type SitemapFetcher func(ctx context.Context, sitemapURL string) (io.ReadCloser, error)
+
+// SitemapExpandOptions controls how ExpandSitemaps turns sitemaps into a list of pages
+type SitemapExpandOptions struct {
+	ModifiedSince time.Time         // If set, pages and sitemaps with an earlier lastmod are skipped. Entries without lastmod are kept.
+	AllowPage     func(string) bool // If set, only the pages it allows are kept
+	AllowSitemap  func(string) bool // If set, only the sitemaps it allows are fetched
+	MaxURLs       int               // Maximum number of pages to return, 0 for no limit
+	MaxDepth      int               // Maximum nesting of sitemap indexes, SitemapDefaultMaxDepth if 0
+	MaxSitemaps   int               // Maximum number of sitemaps to fetch, SitemapDefaultMaxSitemaps if 0
+
+	// OnSitemapError, if set, is called for each sitemap listed in an index that could not be fetched or parsed.
+	// Such sitemaps are skipped.
+	OnSitemapError func(sitemapURL string, err error)
+}
+
+// ExpandSitemaps fetches the given sitemaps, following sitemap indexes, and returns the pages they list in order and
+// without duplicates. It fails if one of the given sitemaps cannot be fetched or parsed, while the sitemaps listed in
+// indexes are skipped and reported to OnSitemapError. Once MaxSitemaps sitemaps have been fetched, the remaining ones
+// are ignored.
+func ExpandSitemaps(ctx context.Context, fetch SitemapFetcher, sitemapURLs []string, opts SitemapExpandOptions) ([]SitemapURL, error) {
+	maxDepth := opts.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = SitemapDefaultMaxDepth
+	}
+	maxSitemaps := opts.MaxSitemaps
+	if maxSitemaps == 0 {
+		maxSitemaps = SitemapDefaultMaxSitemaps
+	}
+
+	type pending struct {
+		url   string
+		depth int
+	}
+	queue := make([]pending, len(sitemapURLs))
+	for i, u := range sitemapURLs {
+		queue[i] = pending{url: u}
+	}
+
+	var ret []SitemapURL
+	seenSitemaps := make(map[string]bool)
+	seenPages := make(map[string]bool)
+
+	for len(queue) > 0 && len(seenSitemaps) < maxSitemaps {
+		current := queue[0]
+		queue = queue[1:]
+		if seenSitemaps[current.url] {
+			continue
+		}
+		seenSitemaps[current.url] = true
+
+		sitemap, err := fetchSitemap(ctx, fetch, current.url)
+		if err != nil {
+			if current.depth == 0 || ctx.Err() != nil {
+				return nil, err
+			}
+			if opts.OnSitemapError != nil {
+				opts.OnSitemapError(current.url, err)
+			}
+			continue
+		}
+
+		for _, ref := range sitemap.Sitemaps {
+			loc := resolveSitemapLoc(current.url, ref.Loc)
+			if current.depth >= maxDepth || modifiedBefore(ref.LastMod, opts.ModifiedSince) ||
+				(opts.AllowSitemap != nil && !opts.AllowSitemap(loc)) {
+				continue
+			}
+			queue = append(queue, pending{url: loc, depth: current.depth + 1})
+		}
+
+		for _, page := range sitemap.URLs {
+			page.Loc = resolveSitemapLoc(current.url, page.Loc)
+			if seenPages[page.Loc] || modifiedBefore(page.LastMod, opts.ModifiedSince) || (opts.AllowPage != nil && !opts.AllowPage(page.Loc)) {
+				continue
+			}
+			seenPages[page.Loc] = true
+			ret = append(ret, page)
+			if opts.MaxURLs > 0 && len(ret) >= opts.MaxURLs {
+				return ret, nil
+			}
+		}
+	}
+
+	return ret, nil
+}
+
+// modifiedBefore returns true if the lastmod is known and earlier than since
+func modifiedBefore(lastMod, since time.Time) bool {
+	return !lastMod.IsZero() && lastMod.Before(since)
+}
+
+func fetchSitemap(ctx context.Context, fetch SitemapFetcher, sitemapURL string) (*Sitemap, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	body, err := fetch(ctx, sitemapURL)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sitemap %s: %w", sitemapURL, err)
+	}
+	defer body.Close()
+
+	sitemap, err := ParseSitemap(body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing sitemap %s: %w", sitemapURL, err)
+	}
+	return sitemap, nil
+}
+
+// resolveSitemapLoc resolves loc against the URL of the sitemap listing it. The protocol requires absolute URLs, but
+// relative ones are common.
+func resolveSitemapLoc(base, loc string) string {
+	baseURL, err := url.Parse(base)
+	if err != nil {
+		return loc
+	}
+	locURL, err := url.Parse(loc)
+	if err != nil {
+		return loc
+	}
+	return baseURL.ResolveReference(locURL).String()
+}
+
+// WithSitemapURLs returns a copy of the request that fetches the given pages without following links. Callers must not
+// pass an empty list, which would produce a request without start URLs.
+func (r WebScraperRequest) WithSitemapURLs(pages []SitemapURL) WebScraperRequest {
+	method := "GET"
+	if len(r.StartUrls) > 0 && r.StartUrls[0].Method != "" {
+		method = r.StartUrls[0].Method
+	}
+
+	r.StartUrls = make([]WebStartURL, len(pages))
+	for i, page := range pages {
+		r.StartUrls[i] = WebStartURL{URL: page.Loc, Method: method}
+	}
+	r.MaxCrawlDepth = 0
+	r.MaxCrawlPages = len(pages)
+	return r
+}
@@ -0,0 +1,243 @@
+package types_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+const urlsetXML = `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+  <url>
+    <loc> https://example.com/docs/intro </loc>
+    <lastmod>2024-03-01</lastmod>
+    <changefreq>Weekly</changefreq>
+    <priority>0.8</priority>
+  </url>
+  <url>
+    <loc>https://example.com/docs/install</loc>
+    <lastmod>2024-05-10T08:30:00+02:00</lastmod>
+  </url>
+  <url>
+    <loc>/blog/hello</loc>
+    <lastmod>not a date</lastmod>
+  </url>
+  <url><lastmod>2024-01-01</lastmod></url>
+</urlset>`
+
+func sitemapIndexXML(locs ...string) string {
+	var sb strings.Builder
+	sb.WriteString(`<?xml version="1.0" encoding="UTF-8"?><sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	for _, loc := range locs {
+		loc, lastMod, _ := strings.Cut(loc, "|")
+		fmt.Fprintf(&sb, "<sitemap><loc>%s</loc><lastmod>%s</lastmod></sitemap>", loc, lastMod)
+	}
+	sb.WriteString("</sitemapindex>")
+	return sb.String()
+}
+
+func gzipped(s string) []byte {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, _ = gz.Write([]byte(s))
+	_ = gz.Close()
+	return buf.Bytes()
+}
+
+// fakeSitemapFetcher serves the given documents and records the fetched URLs
+func fakeSitemapFetcher(docs map[string][]byte, fetched *[]string) types.SitemapFetcher {
+	return func(_ context.Context, sitemapURL string) (io.ReadCloser, error) {
+		*fetched = append(*fetched, sitemapURL)
+		doc, ok := docs[sitemapURL]
+		if !ok {
+			return nil, errors.New("404 not found")
+		}
+		return io.NopCloser(bytes.NewReader(doc)), nil
+	}
+}
+
+var _ = Describe("Sitemap", func() {
+	Describe("ParseSitemap", func() {
+		It("should parse a urlset", func() {
+			sitemap, err := types.ParseSitemap(strings.NewReader(urlsetXML))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sitemap.Index).To(BeFalse())
+			Expect(sitemap.URLs).To(HaveLen(3))
+
+			Expect(sitemap.URLs[0].Loc).To(Equal("https://example.com/docs/intro"))
+			Expect(sitemap.URLs[0].LastMod).To(Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)))
+			Expect(sitemap.URLs[0].ChangeFreq).To(Equal("weekly"))
+			Expect(sitemap.URLs[0].Priority).To(Equal(0.8))
+			Expect(sitemap.URLs[1].LastMod.Equal(time.Date(2024, 5, 10, 6, 30, 0, 0, time.UTC))).To(BeTrue())
+			Expect(sitemap.URLs[2].Loc).To(Equal("/blog/hello"))
+			Expect(sitemap.URLs[2].LastMod).To(BeZero())
+		})
+
+		It("should parse a gzipped sitemap index", func() {
+			sitemap, err := types.ParseSitemap(bytes.NewReader(gzipped(sitemapIndexXML("https://example.com/a.xml|2024-02", "https://example.com/b.xml"))))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(sitemap.Index).To(BeTrue())
+			Expect(sitemap.Sitemaps).To(Equal([]types.SitemapRef{
+				{Loc: "https://example.com/a.xml", LastMod: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{Loc: "https://example.com/b.xml"},
+			}))
+		})
+
+		It("should reject documents that are not sitemaps", func() {
+			_, err := types.ParseSitemap(strings.NewReader("<html><body>Not found</body></html>"))
+			Expect(errors.Is(err, types.ErrSitemapInvalid)).To(BeTrue())
+
+			_, err = types.ParseSitemap(strings.NewReader("not xml at all"))
+			Expect(errors.Is(err, types.ErrSitemapInvalid)).To(BeTrue())
+
+			_, err = types.ParseSitemap(bytes.NewReader([]byte{0x1f, 0x8b, 0x00}))
+			Expect(errors.Is(err, types.ErrSitemapInvalid)).To(BeTrue())
+		})
+
+		It("should reject sitemaps over the maximum size", func() {
+			huge := "<urlset>" + strings.Repeat(" ", types.SitemapMaxSize) + "</urlset>"
+			_, err := types.ParseSitemap(bytes.NewReader(gzipped(huge)))
+			Expect(errors.Is(err, types.ErrSitemapTooLarge)).To(BeTrue())
+		})
+	})
+
+	Describe("ExpandSitemaps", func() {
+		var (
+			docs    map[string][]byte
+			fetched []string
+		)
+
+		BeforeEach(func() {
+			fetched = nil
+			docs = map[string][]byte{
+				"https://example.com/sitemap.xml": []byte(sitemapIndexXML(
+					"https://example.com/docs.xml.gz|2024-06-01",
+					"https://example.com/old.xml|2020-01-01",
+					"https://example.com/sitemap.xml",
+				)),
+				"https://example.com/docs.xml.gz": gzipped(urlsetXML),
+				"https://example.com/old.xml":     []byte(`<urlset><url><loc>https://example.com/old</loc></url></urlset>`),
+			}
+		})
+
+		It("should follow indexes and resolve relative locations", func() {
+			pages, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/sitemap.xml"}, types.SitemapExpandOptions{})
+			Expect(err).ToNot(HaveOccurred())
+
+			var locs []string
+			for _, page := range pages {
+				locs = append(locs, page.Loc)
+			}
+			Expect(locs).To(Equal([]string{
+				"https://example.com/docs/intro",
+				"https://example.com/docs/install",
+				"https://example.com/blog/hello",
+				"https://example.com/old",
+			}))
+			// The index listing itself is not fetched twice
+			Expect(fetched).To(Equal([]string{"https://example.com/sitemap.xml", "https://example.com/docs.xml.gz", "https://example.com/old.xml"}))
+		})
+
+		It("should skip pages and sitemaps modified before the cutoff, keeping those without lastmod", func() {
+			opts := types.SitemapExpandOptions{ModifiedSince: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)}
+			pages, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/sitemap.xml"}, opts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pages).To(HaveLen(2))
+			Expect(pages[0].Loc).To(Equal("https://example.com/docs/install"))
+			Expect(pages[1].Loc).To(Equal("https://example.com/blog/hello"))
+			Expect(fetched).ToNot(ContainElement("https://example.com/old.xml"))
+		})
+
+		It("should apply the filters and the limits", func() {
+			opts := types.SitemapExpandOptions{
+				AllowPage:    func(u string) bool { return strings.Contains(u, "/docs/") },
+				AllowSitemap: func(u string) bool { return u != "https://example.com/old.xml" },
+				MaxURLs:      1,
+			}
+			pages, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/sitemap.xml"}, opts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pages).To(HaveLen(1))
+			Expect(pages[0].Loc).To(Equal("https://example.com/docs/intro"))
+			Expect(fetched).ToNot(ContainElement("https://example.com/old.xml"))
+		})
+
+		It("should limit the nesting of indexes", func() {
+			docs["https://example.com/nested.xml"] = []byte(sitemapIndexXML("https://example.com/sitemap.xml"))
+			docs["https://example.com/sitemap.xml"] = []byte(sitemapIndexXML("https://example.com/old.xml"))
+			_, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/nested.xml"}, types.SitemapExpandOptions{MaxDepth: 1})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetched).To(Equal([]string{"https://example.com/nested.xml", "https://example.com/sitemap.xml"}))
+		})
+
+		It("should fail when a sitemap cannot be fetched or parsed", func() {
+			_, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/missing.xml"}, types.SitemapExpandOptions{})
+			Expect(err).To(MatchError(ContainSubstring("https://example.com/missing.xml")))
+
+			docs["https://example.com/bad.xml"] = []byte("<html></html>")
+			_, err = types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/bad.xml"}, types.SitemapExpandOptions{})
+			Expect(errors.Is(err, types.ErrSitemapInvalid)).To(BeTrue())
+		})
+
+		It("should skip and report the listed sitemaps that fail", func() {
+			docs["https://example.com/sitemap.xml"] = []byte(sitemapIndexXML("https://example.com/missing.xml", "https://example.com/old.xml"))
+			var failed []string
+			opts := types.SitemapExpandOptions{OnSitemapError: func(sitemapURL string, err error) {
+				failed = append(failed, sitemapURL)
+				Expect(err).To(MatchError(ContainSubstring("404")))
+			}}
+			pages, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/sitemap.xml"}, opts)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(pages).To(HaveLen(1))
+			Expect(pages[0].Loc).To(Equal("https://example.com/old"))
+			Expect(failed).To(Equal([]string{"https://example.com/missing.xml"}))
+		})
+
+		It("should limit the number of fetched sitemaps", func() {
+			_, err := types.ExpandSitemaps(context.Background(), fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/sitemap.xml"}, types.SitemapExpandOptions{MaxSitemaps: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(fetched).To(Equal([]string{"https://example.com/sitemap.xml", "https://example.com/docs.xml.gz"}))
+		})
+
+		It("should stop when the context is done", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err := types.ExpandSitemaps(ctx, fakeSitemapFetcher(docs, &fetched), []string{"https://example.com/sitemap.xml"}, types.SitemapExpandOptions{})
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(fetched).To(BeEmpty())
+		})
+	})
+
+	It("should not marshal a missing lastmod", func() {
+		data, err := json.Marshal(types.SitemapURL{Loc: "https://example.com/"})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"loc":"https://example.com/"}`))
+	})
+
+	It("should build a request that fetches the pages without following links", func() {
+		req := types.WebScraperRequest{
+			StartUrls:     []types.WebStartURL{{URL: "https://example.com/sitemap.xml", Method: "GET"}},
+			MaxCrawlDepth: 2,
+			MaxCrawlPages: 100,
+			SaveMarkdown:  true,
+		}.WithSitemapURLs([]types.SitemapURL{{Loc: "https://example.com/a"}, {Loc: "https://example.com/b"}})
+
+		Expect(req.StartUrls).To(Equal([]types.WebStartURL{
+			{URL: "https://example.com/a", Method: "GET"},
+			{URL: "https://example.com/b", Method: "GET"},
+		}))
+		Expect(req.MaxCrawlDepth).To(BeZero())
+		Expect(req.MaxCrawlPages).To(Equal(2))
+		Expect(req.SaveMarkdown).To(BeTrue())
+	})
+})
@@ -0,0 +1,147 @@
+package types
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+)
+
+var ErrStreamTrailingData = errors.New("unexpected data after the end of the JSON array")
+
+// StreamError is returned by DecodeStream when an item cannot be decoded
+type StreamError struct {
+	Index int   // 0-based index of the item in the stream
+	Err   error // Underlying decoding error
+	Fatal bool  // The stream itself is malformed, so no further items can be read
+}
+
+// Error implements the error interface
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("item %d: %v", e.Index, e.Err)
+}
+
+// Unwrap allows errors.Is and errors.As to match the underlying error
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeStream returns an iterator over the items of type T read from r, which can hold either a JSON array or
+// newline-delimited JSON (one item per line, blank lines are skipped). Only one item is held in memory at a time, so
+// it is suitable for datasets too large to decode with a single json.Unmarshal. Custom UnmarshalJSON methods (e.g.
+// RedditItem's) are honoured.
+//
+// Decoding errors are yielded as a *StreamError carrying the index of the failed item, together with the zero value
+// of T. If the item was well-formed JSON but did not match T the iteration continues with the next item; if the
+// stream itself is malformed (or r fails) the error is marked as Fatal and the iteration stops.
+func DecodeStream[T any](r io.Reader) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		br := bufio.NewReader(r)
+
+		first, err := peekNonSpace(br)
+		if err == io.EOF {
+			return
+		}
+		if err != nil {
+			var zero T
+			yield(zero, &StreamError{Index: 0, Err: err, Fatal: true})
+			return
+		}
+
+		if first == '[' {
+			decodeJSONArray(br, yield)
+		} else {
+			decodeNDJSON(br, yield)
+		}
+	}
+}
+
+// peekNonSpace discards leading whitespace from br and returns the next byte without consuming it
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if !isJSONSpace(b) {
+			return b, br.UnreadByte()
+		}
+	}
+}
+
+func isJSONSpace(b byte) bool {
+	return b == ' ' || b == '\t' || b == '\r' || b == '\n'
+}
+
+// decodeItem decodes a single well-formed item, yielding it or its error. It returns false if the iteration must stop.
+func decodeItem[T any](index int, raw []byte, yield func(T, error) bool) bool {
+	var item T
+	if err := json.Unmarshal(raw, &item); err != nil {
+		var zero T
+		return yield(zero, &StreamError{Index: index, Err: err})
+	}
+	return yield(item, nil)
+}
+
+func decodeJSONArray[T any](r io.Reader, yield func(T, error) bool) {
+	var zero T
+	dec := json.NewDecoder(r)
+
+	// Opening bracket, already checked by the caller
+	if _, err := dec.Token(); err != nil {
+		yield(zero, &StreamError{Index: 0, Err: err, Fatal: true})
+		return
+	}
+
+	index := 0
+	for ; dec.More(); index++ {
+		// Splitting the raw read from the unmarshaling lets us tell a malformed stream from a mismatched item
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			yield(zero, &StreamError{Index: index, Err: err, Fatal: true})
+			return
+		}
+		if !decodeItem(index, raw, yield) {
+			return
+		}
+	}
+
+	// Closing bracket, then nothing but whitespace
+	if _, err := dec.Token(); err != nil {
+		yield(zero, &StreamError{Index: index, Err: err, Fatal: true})
+		return
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = ErrStreamTrailingData
+		}
+		yield(zero, &StreamError{Index: index, Err: err, Fatal: true})
+	}
+}
+
+func decodeNDJSON[T any](br *bufio.Reader, yield func(T, error) bool) {
+	index := 0
+	for {
+		line, err := br.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			var zero T
+			yield(zero, &StreamError{Index: index, Err: err, Fatal: true})
+			return
+		}
+
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			// Each line is self-contained, so even a syntax error only affects its own item
+			if !decodeItem(index, line, yield) {
+				return
+			}
+			index++
+		}
+
+		if err == io.EOF {
+			return
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package types_test
+
+import (
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("DecodeStream", func() {
+	It("should decode a JSON array of polymorphic Reddit items", func() {
+		input := ` [{"type":"post","id":"t3_a","title":"Post"}, {"type":"comment","id":"t1_b","parentId":"t3_a"}] `
+
+		var items []types.RedditItem
+		for item, err := range types.DecodeStream[types.RedditItem](strings.NewReader(input)) {
+			Expect(err).NotTo(HaveOccurred())
+			items = append(items, item)
+		}
+		Expect(items).To(HaveLen(2))
+		Expect(items[0].Post.Title).To(Equal("Post"))
+		Expect(items[1].Comment.ParentID).To(Equal("t3_a"))
+	})
+
+	It("should decode newline-delimited JSON, skipping blank lines", func() {
+		input := "{\"url\":\"https://a.com\"}\n\n{\"url\":\"https://b.com\"}\r\n{\"url\":\"https://c.com\"}"
+
+		var urls []string
+		for item, err := range types.DecodeStream[types.WebScraperResult](strings.NewReader(input)) {
+			Expect(err).NotTo(HaveOccurred())
+			urls = append(urls, item.URL)
+		}
+		Expect(urls).To(Equal([]string{"https://a.com", "https://b.com", "https://c.com"}))
+	})
+
+	It("should report the index of bad items and continue", func() {
+		input := `[{"type":"post","id":"a"},{"type":"unknown"},{"type":"user","id":"c"}]`
+
+		var errs []error
+		count := 0
+		for _, err := range types.DecodeStream[types.RedditItem](strings.NewReader(input)) {
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			count++
+		}
+		Expect(count).To(Equal(2))
+		Expect(errs).To(HaveLen(1))
+
+		var streamErr *types.StreamError
+		Expect(errors.As(errs[0], &streamErr)).To(BeTrue())
+		Expect(streamErr.Index).To(Equal(1))
+		Expect(streamErr.Fatal).To(BeFalse())
+		Expect(streamErr.Error()).To(ContainSubstring("unknown Reddit response type"))
+	})
+
+	It("should recover from a malformed NDJSON line", func() {
+		input := "{\"url\":\"https://a.com\"}\n{not json\n{\"url\":\"https://c.com\"}\n"
+
+		var urls []string
+		var errIndexes []int
+		for item, err := range types.DecodeStream[types.WebScraperResult](strings.NewReader(input)) {
+			var streamErr *types.StreamError
+			if errors.As(err, &streamErr) {
+				errIndexes = append(errIndexes, streamErr.Index)
+				continue
+			}
+			urls = append(urls, item.URL)
+		}
+		Expect(urls).To(Equal([]string{"https://a.com", "https://c.com"}))
+		Expect(errIndexes).To(Equal([]int{1}))
+	})
+
+	It("should stop on a truncated array", func() {
+		input := `[{"url":"https://a.com"},{"url":`
+
+		var errs []*types.StreamError
+		for _, err := range types.DecodeStream[types.WebScraperResult](strings.NewReader(input)) {
+			var streamErr *types.StreamError
+			if errors.As(err, &streamErr) {
+				errs = append(errs, streamErr)
+			}
+		}
+		Expect(errs).To(HaveLen(1))
+		Expect(errs[0].Index).To(Equal(1))
+		Expect(errs[0].Fatal).To(BeTrue())
+	})
+
+	It("should reject data after the array", func() {
+		var lastErr error
+		for _, err := range types.DecodeStream[types.WebScraperResult](strings.NewReader(`[] {}`)) {
+			lastErr = err
+		}
+		Expect(errors.Is(lastErr, types.ErrStreamTrailingData)).To(BeTrue())
+	})
+
+	It("should stop when the consumer breaks", func() {
+		count := 0
+		for range types.DecodeStream[types.WebScraperResult](strings.NewReader("{}\n{}\n{}\n")) {
+			count++
+			break
+		}
+		Expect(count).To(Equal(1))
+	})
+
+	It("should yield nothing for empty input", func() {
+		for range types.DecodeStream[types.WebScraperResult](strings.NewReader(" \n ")) {
+			Fail("unexpected item")
+		}
+	})
+})
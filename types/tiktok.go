@@ -1,6 +1,12 @@
 // Package types provides shared types between tee-worker and tee-indexer
 package types
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
 // TikTokTranscriptionResult defines the structure of the result data for a TikTok transcription
 type TikTokTranscriptionResult struct {
 	TranscriptionText string `json:"transcription_text"`
@@ -8,55 +14,73 @@ type TikTokTranscriptionResult struct {
 	VideoTitle        string `json:"video_title,omitempty"`
 	OriginalURL       string `json:"original_url"`
 	ThumbnailURL      string `json:"thumbnail_url,omitempty"`
+	// Segments is the timed transcript, if the transcriber provides timings
+	Segments TranscriptSegments `json:"segments,omitempty"`
+}
+
+// TikTokSearchRequest represents the input for the epctex/tiktok-search-scraper actor
+type TikTokSearchRequest struct {
+	Search    []string `json:"search,omitempty"`
+	StartUrls []string `json:"startUrls,omitempty"`
+	MaxItems  uint     `json:"maxItems,omitempty"`
+	EndPage   uint     `json:"endPage,omitempty"`
+}
+
+// TikTokTrendingRequest represents the input for the lexis-solutions/tiktok-trending-videos-scraper actor
+type TikTokTrendingRequest struct {
+	CountryCode string `json:"countryCode"`
+	Sort        string `json:"sort"`
+	MaxItems    int    `json:"maxItems,omitempty"`
+	Period      string `json:"period"`
 }
 
 type TikTokSearchByQueryResult struct {
-	URL                   string            `json:"url"`
-	ID                    string            `json:"id"`
-	Desc                  string            `json:"desc"`
-	CreateTime            string            `json:"createTime"`
-	ScheduleTime          int64             `json:"scheduleTime"`
-	Video                 TikTokVideo       `json:"video"`
-	Author                string            `json:"author"`
-	Music                 TikTokMusic       `json:"music"`
-	Challenges            []any             `json:"challenges"` // we don't have examples of this data yet...
-	Stats                 TikTokStats       `json:"stats"`
-	IsActivityItem        bool              `json:"isActivityItem"`
-	DuetInfo              TikTokDuetInfo    `json:"duetInfo"`
-	WarnInfo              []any             `json:"warnInfo"` // we don't have examples of this data yet...
-	OriginalItem          bool              `json:"originalItem"`
-	OfficialItem          bool              `json:"officalItem"`
-	TextExtra             []TikTokTextExtra `json:"textExtra"`
-	Secret                bool              `json:"secret"`
-	ForFriend             bool              `json:"forFriend"`
-	Digged                bool              `json:"digged"`
-	ItemCommentStatus     int               `json:"itemCommentStatus"`
-	ShowNotPass           bool              `json:"showNotPass"`
-	VL1                   bool              `json:"vl1"`
-	TakeDown              int               `json:"takeDown"`
-	ItemMute              bool              `json:"itemMute"`
-	EffectStickers        []any             `json:"effectStickers"` // we don't have examples of this data yet...
-	AuthorStats           TikTokAuthorStats `json:"authorStats"`
-	PrivateItem           bool              `json:"privateItem"`
-	DuetEnabled           bool              `json:"duetEnabled"`
-	StitchEnabled         bool              `json:"stitchEnabled"`
-	StickersOnItem        []any             `json:"stickersOnItem"` // we don't have examples of this data yet...
-	IsAd                  bool              `json:"isAd"`
-	ShareEnabled          bool              `json:"shareEnabled"`
-	Comments              []any             `json:"comments"` // we don't have examples of this data yet...
-	DuetDisplay           int               `json:"duetDisplay"`
-	StitchDisplay         int               `json:"stitchDisplay"`
-	IndexEnabled          bool              `json:"indexEnabled"`
-	DiversificationLabels []string          `json:"diversificationLabels"`
-	AdAuthorization       bool              `json:"adAuthorization"`
-	AdLabelVersion        int               `json:"adLabelVersion"`
-	LocationCreated       string            `json:"locationCreated"`
-	Nickname              string            `json:"nickname"`
-	AuthorID              string            `json:"authorId"`
-	AuthorSecID           string            `json:"authorSecId"`
-	AvatarThumb           string            `json:"avatarThumb"`
-	DownloadSetting       int               `json:"downloadSetting"`
-	AuthorPrivate         bool              `json:"authorPrivate"`
+	URL                   string                `json:"url"`
+	ID                    string                `json:"id"`
+	Desc                  string                `json:"desc"`
+	CreateTime            string                `json:"createTime"`
+	ScheduleTime          int64                 `json:"scheduleTime"`
+	Video                 TikTokVideo           `json:"video"`
+	Author                string                `json:"author"`
+	Music                 TikTokMusic           `json:"music"`
+	Challenges            []TikTokChallenge     `json:"challenges"`
+	Stats                 TikTokStats           `json:"stats"`
+	IsActivityItem        bool                  `json:"isActivityItem"`
+	DuetInfo              TikTokDuetInfo        `json:"duetInfo"`
+	WarnInfo              []TikTokWarnInfo      `json:"warnInfo"`
+	OriginalItem          bool                  `json:"originalItem"`
+	OfficialItem          bool                  `json:"officalItem"`
+	TextExtra             []TikTokTextExtra     `json:"textExtra"`
+	Secret                bool                  `json:"secret"`
+	ForFriend             bool                  `json:"forFriend"`
+	Digged                bool                  `json:"digged"`
+	ItemCommentStatus     int                   `json:"itemCommentStatus"`
+	ShowNotPass           bool                  `json:"showNotPass"`
+	VL1                   bool                  `json:"vl1"`
+	TakeDown              int                   `json:"takeDown"`
+	ItemMute              bool                  `json:"itemMute"`
+	EffectStickers        []TikTokEffectSticker `json:"effectStickers"`
+	AuthorStats           TikTokAuthorStats     `json:"authorStats"`
+	PrivateItem           bool                  `json:"privateItem"`
+	DuetEnabled           bool                  `json:"duetEnabled"`
+	StitchEnabled         bool                  `json:"stitchEnabled"`
+	StickersOnItem        []TikTokStickerOnItem `json:"stickersOnItem"`
+	IsAd                  bool                  `json:"isAd"`
+	ShareEnabled          bool                  `json:"shareEnabled"`
+	Comments              []TikTokComment       `json:"comments"`
+	DuetDisplay           int                   `json:"duetDisplay"`
+	StitchDisplay         int                   `json:"stitchDisplay"`
+	IndexEnabled          bool                  `json:"indexEnabled"`
+	DiversificationLabels []string              `json:"diversificationLabels"`
+	AdAuthorization       bool                  `json:"adAuthorization"`
+	AdLabelVersion        int                   `json:"adLabelVersion"`
+	LocationCreated       string                `json:"locationCreated"`
+	Nickname              string                `json:"nickname"`
+	AuthorID              string                `json:"authorId"`
+	AuthorSecID           string                `json:"authorSecId"`
+	AvatarThumb           string                `json:"avatarThumb"`
+	DownloadSetting       int                   `json:"downloadSetting"`
+	AuthorPrivate         bool                  `json:"authorPrivate"`
 }
 
 type TikTokSearchByTrending struct {
@@ -71,29 +95,29 @@ type TikTokSearchByTrending struct {
 }
 
 type TikTokVideo struct {
-	ID            string              `json:"id"`
-	Height        int                 `json:"height"`
-	Width         int                 `json:"width"`
-	Duration      int                 `json:"duration"`
-	Ratio         string              `json:"ratio"`
-	Cover         string              `json:"cover"`
-	OriginCover   string              `json:"origin_cover"`
-	DynamicCover  string              `json:"dynamic_cover"`
-	PlayAddr      string              `json:"play_addr"`
-	DownloadAddr  string              `json:"download_addr"`
-	ShareCover    []string            `json:"share_cover"`
-	ReflowCover   string              `json:"reflowCover"`
-	Bitrate       int                 `json:"bitrate"`
-	EncodedType   string              `json:"encodedType"`
-	Format        string              `json:"format"`
-	VideoQuality  string              `json:"videoQuality"`
-	EncodeUserTag string              `json:"encodeUserTag"`
-	CodecType     string              `json:"codecType"`
-	Definition    string              `json:"definition"`
-	SubtitleInfos []any               `json:"subtitleInfos"` // we don't have examples of this data yet...
-	ZoomCover     TikTokZoomCover     `json:"zoomCover"`
-	VolumeInfo    TikTokVolumeInfo    `json:"volumeInfo"`
-	BitrateInfo   []TikTokBitrateInfo `json:"bitrateInfo"`
+	ID            string               `json:"id"`
+	Height        int                  `json:"height"`
+	Width         int                  `json:"width"`
+	Duration      int                  `json:"duration"`
+	Ratio         string               `json:"ratio"`
+	Cover         string               `json:"cover"`
+	OriginCover   string               `json:"origin_cover"`
+	DynamicCover  string               `json:"dynamic_cover"`
+	PlayAddr      string               `json:"play_addr"`
+	DownloadAddr  string               `json:"download_addr"`
+	ShareCover    []string             `json:"share_cover"`
+	ReflowCover   string               `json:"reflowCover"`
+	Bitrate       int                  `json:"bitrate"`
+	EncodedType   string               `json:"encodedType"`
+	Format        string               `json:"format"`
+	VideoQuality  string               `json:"videoQuality"`
+	EncodeUserTag string               `json:"encodeUserTag"`
+	CodecType     string               `json:"codecType"`
+	Definition    string               `json:"definition"`
+	SubtitleInfos []TikTokSubtitleInfo `json:"subtitleInfos"`
+	ZoomCover     TikTokZoomCover      `json:"zoomCover"`
+	VolumeInfo    TikTokVolumeInfo     `json:"volumeInfo"`
+	BitrateInfo   []TikTokBitrateInfo  `json:"bitrateInfo"`
 }
 
 type TikTokZoomCover struct {
@@ -172,3 +196,199 @@ type TikTokAuthorStats struct {
 	VideoCount     int64 `json:"videoCount"`
 	DiggCount      int64 `json:"diggCount"`
 }
+
+// The following types describe nested TikTok data whose shape is not documented and varies between scrapers. They are
+// decoded tolerantly: if an item does not match the expected shape its typed fields are left empty and the original
+// JSON is kept in Raw, instead of failing the whole result.
+
+// TikTokChallenge is a hashtag challenge attached to a video
+type TikTokChallenge struct {
+	ID            string `json:"id"`
+	Title         string `json:"title"` // The hashtag, without the leading '#'
+	Desc          string `json:"desc"`
+	ProfileLarger string `json:"profileLarger"`
+	ProfileMedium string `json:"profileMedium"`
+	ProfileThumb  string `json:"profileThumb"`
+	CoverLarger   string `json:"coverLarger"`
+	CoverMedium   string `json:"coverMedium"`
+	CoverThumb    string `json:"coverThumb"`
+	IsCommerce    bool   `json:"isCommerce"`
+
+	Raw json.RawMessage `json:"-"` // Original JSON, set only if it could not be decoded
+}
+
+// TikTokWarnInfo is a content warning displayed on a video
+type TikTokWarnInfo struct {
+	Type int    `json:"type"`
+	Key  string `json:"key"`
+	Text string `json:"text"`
+	URL  string `json:"url"`
+
+	Raw json.RawMessage `json:"-"` // Original JSON, set only if it could not be decoded
+}
+
+// TikTokEffectSticker is an effect used when recording a video
+type TikTokEffectSticker struct {
+	ID           string                   `json:"ID"`
+	Name         string                   `json:"name"`
+	StickerStats TikTokEffectStickerStats `json:"stickerStats"`
+
+	Raw json.RawMessage `json:"-"` // Original JSON, set only if it could not be decoded
+}
+
+type TikTokEffectStickerStats struct {
+	UseCount int64 `json:"useCount"`
+}
+
+// TikTokStickerOnItem is a sticker overlaid on a video, e.g. a text sticker
+type TikTokStickerOnItem struct {
+	StickerType int      `json:"stickerType"`
+	StickerText []string `json:"stickerText"`
+
+	Raw json.RawMessage `json:"-"` // Original JSON, set only if it could not be decoded
+}
+
+// TikTokComment is a comment on a video
+type TikTokComment struct {
+	CID          string              `json:"cid"`
+	Text         string              `json:"text"`
+	CreateTime   int64               `json:"create_time"` // Unix timestamp in seconds
+	DiggCount    int64               `json:"digg_count"`
+	ReplyCount   int64               `json:"reply_comment_total"`
+	ReplyToID    string              `json:"reply_id"` // "0" for top-level comments
+	IsAuthorLike bool                `json:"is_author_digged"`
+	User         TikTokCommentAuthor `json:"user"`
+
+	Raw json.RawMessage `json:"-"` // Original JSON, set only if it could not be decoded
+}
+
+// TikTokCommentAuthor is the author of a TikTokComment
+type TikTokCommentAuthor struct {
+	UID      string `json:"uid"`
+	UniqueID string `json:"unique_id"` // Username
+	Nickname string `json:"nickname"`
+	SecUID   string `json:"sec_uid"`
+}
+
+// CreatedAt returns the creation time of the comment, or the zero time if it is unknown
+func (c *TikTokComment) CreatedAt() time.Time {
+	if c.CreateTime <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(c.CreateTime, 0).UTC()
+}
+
+// TikTokSubtitleInfo describes a subtitle track of a video
+type TikTokSubtitleInfo struct {
+	LanguageID       string `json:"LanguageID"`
+	LanguageCodeName string `json:"LanguageCodeName"` // e.g. "eng-US"
+	URL              string `json:"Url"`
+	URLExpire        string `json:"UrlExpire"`
+	Format           string `json:"Format"` // e.g. "webvtt"
+	Version          string `json:"Version"`
+	Source           string `json:"Source"` // e.g. "ASR" for automatic speech recognition, "MT" for machine translation
+	Size             string `json:"Size"`
+
+	Raw json.RawMessage `json:"-"` // Original JSON, set only if it could not be decoded
+}
+
+// unmarshalTolerant decodes data into v, which must be an alias of the type owning raw so its UnmarshalJSON is not
+// called recursively. If data does not match the type, v is reset and data is kept in raw instead.
+func unmarshalTolerant[T any](data []byte, v *T, raw *json.RawMessage) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		var zero T
+		*v = zero
+		*raw = append(json.RawMessage(nil), data...)
+	}
+	return nil
+}
+
+// marshalTolerant encodes v, or the original JSON if the value was not decoded
+func marshalTolerant[T any](v T, raw json.RawMessage) ([]byte, error) {
+	if len(raw) > 0 {
+		return raw, nil
+	}
+	return json.Marshal(v)
+}
+
+func (c *TikTokChallenge) UnmarshalJSON(data []byte) error {
+	type Alias TikTokChallenge
+	return unmarshalTolerant(data, (*Alias)(c), &c.Raw)
+}
+
+func (c TikTokChallenge) MarshalJSON() ([]byte, error) {
+	type Alias TikTokChallenge
+	return marshalTolerant(Alias(c), c.Raw)
+}
+
+func (w *TikTokWarnInfo) UnmarshalJSON(data []byte) error {
+	type Alias TikTokWarnInfo
+	return unmarshalTolerant(data, (*Alias)(w), &w.Raw)
+}
+
+func (w TikTokWarnInfo) MarshalJSON() ([]byte, error) {
+	type Alias TikTokWarnInfo
+	return marshalTolerant(Alias(w), w.Raw)
+}
+
+func (e *TikTokEffectSticker) UnmarshalJSON(data []byte) error {
+	type Alias TikTokEffectSticker
+	return unmarshalTolerant(data, (*Alias)(e), &e.Raw)
+}
+
+func (e TikTokEffectSticker) MarshalJSON() ([]byte, error) {
+	type Alias TikTokEffectSticker
+	return marshalTolerant(Alias(e), e.Raw)
+}
+
+func (s *TikTokStickerOnItem) UnmarshalJSON(data []byte) error {
+	type Alias TikTokStickerOnItem
+	return unmarshalTolerant(data, (*Alias)(s), &s.Raw)
+}
+
+func (s TikTokStickerOnItem) MarshalJSON() ([]byte, error) {
+	type Alias TikTokStickerOnItem
+	return marshalTolerant(Alias(s), s.Raw)
+}
+
+func (c *TikTokComment) UnmarshalJSON(data []byte) error {
+	type Alias TikTokComment
+	return unmarshalTolerant(data, (*Alias)(c), &c.Raw)
+}
+
+func (c TikTokComment) MarshalJSON() ([]byte, error) {
+	type Alias TikTokComment
+	return marshalTolerant(Alias(c), c.Raw)
+}
+
+func (s *TikTokSubtitleInfo) UnmarshalJSON(data []byte) error {
+	type Alias TikTokSubtitleInfo
+	return unmarshalTolerant(data, (*Alias)(s), &s.Raw)
+}
+
+func (s TikTokSubtitleInfo) MarshalJSON() ([]byte, error) {
+	type Alias TikTokSubtitleInfo
+	return marshalTolerant(Alias(s), s.Raw)
+}
+
+// Hashtags returns the hashtags of the video, from both its description and its challenges, without duplicates
+func (t *TikTokSearchByQueryResult) Hashtags() []string {
+	var ret []string
+	seen := make(map[string]bool)
+	add := func(tag string) {
+		key := strings.ToLower(tag)
+		if tag == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		ret = append(ret, tag)
+	}
+
+	for _, extra := range t.TextExtra {
+		add(extra.HashtagName)
+	}
+	for _, challenge := range t.Challenges {
+		add(challenge.Title)
+	}
+	return ret
+}
@@ -0,0 +1,68 @@
+package types_test
+
+import (
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("TikTokSearchByQueryResult", func() {
+	const resultJSON = `{
+		"id": "123",
+		"challenges": [{"id": "1", "title": "fyp", "desc": "For you"}, {"id": "2", "title": "dance"}],
+		"textExtra": [{"hashtagName": "FYP"}],
+		"warnInfo": [{"type": 1, "text": "Sensitive content"}],
+		"effectStickers": [{"ID": "9", "name": "Green Screen", "stickerStats": {"useCount": 42}}],
+		"stickersOnItem": [{"stickerType": 4, "stickerText": ["hello"]}],
+		"comments": [{"cid": "c1", "text": "nice", "create_time": 1700000000, "digg_count": 3, "user": {"unique_id": "fan", "nickname": "Fan"}}],
+		"video": {"subtitleInfos": [{"LanguageCodeName": "eng-US", "Url": "https://v.tiktok.com/sub.vtt", "Format": "webvtt", "Source": "ASR"}]}
+	}`
+
+	It("should decode the typed nested data", func() {
+		var result types.TikTokSearchByQueryResult
+		Expect(json.Unmarshal([]byte(resultJSON), &result)).To(Succeed())
+
+		Expect(result.Challenges[0].Title).To(Equal("fyp"))
+		Expect(result.Challenges[0].Raw).To(BeNil())
+		Expect(result.WarnInfo[0].Text).To(Equal("Sensitive content"))
+		Expect(result.EffectStickers[0].StickerStats.UseCount).To(Equal(int64(42)))
+		Expect(result.StickersOnItem[0].StickerText).To(Equal([]string{"hello"}))
+
+		comment := result.Comments[0]
+		Expect(comment.Text).To(Equal("nice"))
+		Expect(comment.DiggCount).To(Equal(int64(3)))
+		Expect(comment.User.UniqueID).To(Equal("fan"))
+		Expect(comment.CreatedAt()).To(Equal(time.Unix(1700000000, 0).UTC()))
+
+		Expect(result.Video.SubtitleInfos[0].LanguageCodeName).To(Equal("eng-US"))
+		Expect(result.Video.SubtitleInfos[0].URL).To(Equal("https://v.tiktok.com/sub.vtt"))
+	})
+
+	It("should merge hashtags from the description and the challenges", func() {
+		var result types.TikTokSearchByQueryResult
+		Expect(json.Unmarshal([]byte(resultJSON), &result)).To(Succeed())
+		Expect(result.Hashtags()).To(Equal([]string{"FYP", "dance"}))
+	})
+
+	It("should fall back to the raw JSON for unknown shapes", func() {
+		var result types.TikTokSearchByQueryResult
+		input := `{"challenges": ["fyp", {"title": "dance"}], "comments": [{"cid": 5, "text": "typed"}]}`
+		Expect(json.Unmarshal([]byte(input), &result)).To(Succeed())
+
+		Expect(result.Challenges).To(HaveLen(2))
+		Expect(result.Challenges[0].Title).To(BeEmpty())
+		Expect(string(result.Challenges[0].Raw)).To(Equal(`"fyp"`))
+		Expect(result.Challenges[1].Title).To(Equal("dance"))
+		Expect(result.Comments[0].Text).To(BeEmpty())
+		Expect(result.Comments[0].Raw).NotTo(BeNil())
+
+		// The raw JSON is preserved when marshaling back
+		data, err := json.Marshal(result.Challenges)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(HavePrefix(`["fyp",{`))
+	})
+})
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var (
+	ErrTikTokURLInvalid = errors.New("invalid URL")
+	ErrNotTikTokURL     = errors.New("not a TikTok URL")
+)
+
+type TikTokURLKind string
+
+const (
+	TikTokVideoURL   TikTokURLKind = "video"
+	TikTokProfileURL TikTokURLKind = "profile"
+	TikTokHashtagURL TikTokURLKind = "hashtag"
+	TikTokMusicURL   TikTokURLKind = "music"
+	TikTokSearchURL  TikTokURLKind = "search"
+	TikTokShortURL   TikTokURLKind = "short" // vm.tiktok.com, vt.tiktok.com and tiktok.com/t/ links, which redirect to the real URL
+	TikTokOtherURL   TikTokURLKind = "other" // any other page on tiktok.com
+)
+
+const tiktokCanonicalHost = "www.tiktok.com"
+
+var (
+	tiktokShortHosts  = []string{"vm.tiktok.com", "vt.tiktok.com"}
+	tiktokVideoIDRe   = regexp.MustCompile(`^[0-9]+$`)
+	tiktokLegacyRe    = regexp.MustCompile(`^([0-9]+)\.html$`)
+	tiktokMusicIDRe   = regexp.MustCompile(`-([0-9]+)$`)
+	tiktokUsernameRe  = regexp.MustCompile(`^[A-Za-z0-9_.]+$`)
+	tiktokShortCodeRe = regexp.MustCompile(`^[A-Za-z0-9]+$`)
+)
+
+// TikTokURL is a parsed and classified TikTok URL
+type TikTokURL struct {
+	Kind      TikTokURLKind
+	Username  string // without the leading '@', for video and profile URLs
+	VideoID   string // numeric video ID, for video URLs
+	Hashtag   string // for hashtag URLs
+	MusicID   string // numeric music ID, for music URLs
+	MusicSlug string // e.g. "original-sound-7123", for music URLs
+	ShortCode string // for short URLs
+	Raw       string // the URL as it was parsed
+
+	shortBase string // the part of a short URL preceding the ShortCode
+}
+
+// ParseTikTokURL parses and classifies a TikTok URL.
+// It returns ErrNotTikTokURL if the URL is not on tiktok.com, and classifies unknown pages as TikTokOtherURL.
+func ParseTikTokURL(rawURL string) (*TikTokURL, error) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrTikTokURLInvalid, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %s (scheme must be http or https)", ErrTikTokURLInvalid, rawURL)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host != "tiktok.com" && !strings.HasSuffix(host, ".tiktok.com") {
+		return nil, fmt.Errorf("%w: %s", ErrNotTikTokURL, rawURL)
+	}
+
+	ret := &TikTokURL{Kind: TikTokOtherURL, Raw: rawURL}
+
+	var segments []string
+	for _, s := range strings.Split(u.EscapedPath(), "/") {
+		if s == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(s); err == nil {
+			s = unescaped
+		}
+		segments = append(segments, s)
+	}
+
+	isShortHost := false
+	for _, h := range tiktokShortHosts {
+		if host == h {
+			isShortHost = true
+		}
+	}
+
+	switch {
+	case isShortHost:
+		if len(segments) == 1 && tiktokShortCodeRe.MatchString(segments[0]) {
+			ret.Kind = TikTokShortURL
+			ret.ShortCode = segments[0]
+			ret.shortBase = "https://" + host + "/"
+		}
+	case len(segments) == 0:
+		// Home page
+	case strings.HasPrefix(segments[0], "@"):
+		username := strings.TrimPrefix(segments[0], "@")
+		if !tiktokUsernameRe.MatchString(username) {
+			break
+		}
+		switch {
+		case len(segments) == 1:
+			ret.Kind = TikTokProfileURL
+			ret.Username = username
+		case len(segments) >= 3 && segments[1] == "video" && tiktokVideoIDRe.MatchString(segments[2]):
+			ret.Kind = TikTokVideoURL
+			ret.Username = username
+			ret.VideoID = segments[2]
+		}
+	case segments[0] == "t" && len(segments) == 2 && tiktokShortCodeRe.MatchString(segments[1]):
+		ret.Kind = TikTokShortURL
+		ret.ShortCode = segments[1]
+		ret.shortBase = "https://" + tiktokCanonicalHost + "/t/"
+	case segments[0] == "tag" && len(segments) == 2:
+		ret.Kind = TikTokHashtagURL
+		ret.Hashtag = segments[1]
+	case segments[0] == "music" && len(segments) == 2:
+		if m := tiktokMusicIDRe.FindStringSubmatch(segments[1]); m != nil {
+			ret.Kind = TikTokMusicURL
+			ret.MusicSlug = segments[1]
+			ret.MusicID = m[1]
+		}
+	case segments[0] == "search":
+		ret.Kind = TikTokSearchURL
+	case segments[0] == "v" && len(segments) == 2:
+		// Legacy mobile video URLs, e.g. https://m.tiktok.com/v/1234567890.html
+		if m := tiktokLegacyRe.FindStringSubmatch(segments[1]); m != nil {
+			ret.Kind = TikTokVideoURL
+			ret.VideoID = m[1]
+		}
+	case segments[0] == "embed" && len(segments) >= 2 && tiktokVideoIDRe.MatchString(segments[len(segments)-1]):
+		// Embed URLs, e.g. https://www.tiktok.com/embed/v2/1234567890
+		ret.Kind = TikTokVideoURL
+		ret.VideoID = segments[len(segments)-1]
+	}
+
+	return ret, nil
+}
+
+// IsVideo returns true if the URL points to a single video
+func (t *TikTokURL) IsVideo() bool {
+	return t.Kind == TikTokVideoURL
+}
+
+// CanonicalURL returns the canonical form of the URL, without query parameters or tracking fragments.
+// Video URLs become https://www.tiktok.com/@user/video/<id>. URLs of kind TikTokOtherURL are returned as-is.
+func (t *TikTokURL) CanonicalURL() string {
+	switch t.Kind {
+	case TikTokVideoURL:
+		if t.Username == "" {
+			// Without the username TikTok can only resolve the video through the legacy mobile URL
+			return fmt.Sprintf("https://m.tiktok.com/v/%s.html", t.VideoID)
+		}
+		return fmt.Sprintf("https://%s/@%s/video/%s", tiktokCanonicalHost, t.Username, t.VideoID)
+	case TikTokProfileURL:
+		return fmt.Sprintf("https://%s/@%s", tiktokCanonicalHost, t.Username)
+	case TikTokHashtagURL:
+		return fmt.Sprintf("https://%s/tag/%s", tiktokCanonicalHost, url.PathEscape(t.Hashtag))
+	case TikTokMusicURL:
+		return fmt.Sprintf("https://%s/music/%s", tiktokCanonicalHost, url.PathEscape(t.MusicSlug))
+	case TikTokShortURL:
+		return t.shortBase + t.ShortCode + "/"
+	default:
+		return t.Raw
+	}
+}
@@ -0,0 +1,69 @@
+package types_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("TikTokURL", func() {
+	DescribeTable("classifying URLs",
+		func(rawURL string, kind types.TikTokURLKind, canonical string) {
+			u, err := types.ParseTikTokURL(rawURL)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(u.Kind).To(Equal(kind))
+			Expect(u.CanonicalURL()).To(Equal(canonical))
+		},
+		Entry("video", "https://www.tiktok.com/@user.name/video/7234567890123456789?is_from_webapp=1&sender_device=pc",
+			types.TikTokVideoURL, "https://www.tiktok.com/@user.name/video/7234567890123456789"),
+		Entry("mobile video", "http://m.tiktok.com/@user/video/123/",
+			types.TikTokVideoURL, "https://www.tiktok.com/@user/video/123"),
+		Entry("legacy video", "https://m.tiktok.com/v/123.html",
+			types.TikTokVideoURL, "https://m.tiktok.com/v/123.html"),
+		Entry("embed", "https://www.tiktok.com/embed/v2/123",
+			types.TikTokVideoURL, "https://m.tiktok.com/v/123.html"),
+		Entry("profile", "https://tiktok.com/@user?lang=en",
+			types.TikTokProfileURL, "https://www.tiktok.com/@user"),
+		Entry("hashtag", "https://www.tiktok.com/tag/golang",
+			types.TikTokHashtagURL, "https://www.tiktok.com/tag/golang"),
+		Entry("music", "https://www.tiktok.com/music/original-sound-7123",
+			types.TikTokMusicURL, "https://www.tiktok.com/music/original-sound-7123"),
+		Entry("vm short link", "https://vm.tiktok.com/ZMabc123/",
+			types.TikTokShortURL, "https://vm.tiktok.com/ZMabc123/"),
+		Entry("vt short link", "https://vt.tiktok.com/ZSabc123",
+			types.TikTokShortURL, "https://vt.tiktok.com/ZSabc123/"),
+		Entry("t short link", "https://www.tiktok.com/t/ZTabc123/",
+			types.TikTokShortURL, "https://www.tiktok.com/t/ZTabc123/"),
+		Entry("search", "https://www.tiktok.com/search?q=golang",
+			types.TikTokSearchURL, "https://www.tiktok.com/search?q=golang"),
+		Entry("other", "https://www.tiktok.com/about",
+			types.TikTokOtherURL, "https://www.tiktok.com/about"),
+	)
+
+	It("should extract the username and video ID", func() {
+		u, err := types.ParseTikTokURL("https://www.tiktok.com/@masa_finance/video/7234567890123456789")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.IsVideo()).To(BeTrue())
+		Expect(u.Username).To(Equal("masa_finance"))
+		Expect(u.VideoID).To(Equal("7234567890123456789"))
+	})
+
+	It("should extract the music ID", func() {
+		u, err := types.ParseTikTokURL("https://www.tiktok.com/music/original-sound-7123")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(u.MusicID).To(Equal("7123"))
+	})
+
+	It("should reject URLs outside tiktok.com", func() {
+		_, err := types.ParseTikTokURL("https://www.nottiktok.com/@user/video/123")
+		Expect(errors.Is(err, types.ErrNotTikTokURL)).To(BeTrue())
+	})
+
+	It("should reject URLs without an http scheme", func() {
+		_, err := types.ParseTikTokURL("ftp://www.tiktok.com/@user/video/123")
+		Expect(errors.Is(err, types.ErrTikTokURLInvalid)).To(BeTrue())
+	})
+})
@@ -0,0 +1,208 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrTranscriptInvalid = errors.New("invalid transcript")
+
+// TranscriptSegment is a timed piece of a transcript
+type TranscriptSegment struct {
+	Start      float64 `json:"start"` // in seconds from the start of the media
+	End        float64 `json:"end"`   // in seconds from the start of the media
+	Text       string  `json:"text"`
+	Speaker    string  `json:"speaker,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"` // between 0 and 1, 0 if unknown
+}
+
+// TranscriptSegments is a list of transcript segments, in chronological order
+type TranscriptSegments []TranscriptSegment
+
+// Text returns the text of all the segments, separated by spaces
+func (s TranscriptSegments) Text() string {
+	texts := make([]string, 0, len(s))
+	for _, seg := range s {
+		if text := strings.TrimSpace(seg.Text); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, " ")
+}
+
+// SRT encodes the segments as SubRip subtitles. SRT has no notion of speakers or confidence, so they are dropped.
+// SRT has no escaping either: blank lines are removed from the text and "-->" is replaced by "->", so that the text
+// cannot end the cue or be mistaken for a timing line.
+func (s TranscriptSegments) SRT() string {
+	var b strings.Builder
+	for i, seg := range s {
+		text := strings.ReplaceAll(cueText(seg.Text), "-->", "->")
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, formatCueTime(seg.Start, ','), formatCueTime(seg.End, ','), text)
+	}
+	return b.String()
+}
+
+// WebVTT encodes the segments as WebVTT subtitles. Speakers are encoded as voice spans, e.g. "<v Alice>Hello".
+// Blank lines are removed from the text, and '&', '<' and '>' are escaped.
+func (s TranscriptSegments) WebVTT() string {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, seg := range s {
+		text := webVTTEscaper.Replace(cueText(seg.Text))
+		if seg.Speaker != "" {
+			text = "<v " + webVTTEscaper.Replace(strings.Join(strings.Fields(seg.Speaker), " ")) + ">" + text
+		}
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n", formatCueTime(seg.Start, '.'), formatCueTime(seg.End, '.'), text)
+	}
+	return b.String()
+}
+
+// ParseSRT decodes SubRip subtitles into transcript segments
+func ParseSRT(data string) (TranscriptSegments, error) {
+	var ret TranscriptSegments
+	for i, block := range cueBlocks(data) {
+		seg, err := parseCue(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cue %d: %w", ErrTranscriptInvalid, i+1, err)
+		}
+		ret = append(ret, seg)
+	}
+	return ret, nil
+}
+
+// ParseWebVTT decodes WebVTT subtitles into transcript segments. NOTE, STYLE and REGION blocks are skipped, and voice
+// spans are decoded into the segment speaker.
+func ParseWebVTT(data string) (TranscriptSegments, error) {
+	blocks := cueBlocks(data)
+	if len(blocks) == 0 || !strings.HasPrefix(blocks[0][0], "WEBVTT") {
+		return nil, fmt.Errorf("%w: missing WEBVTT header", ErrTranscriptInvalid)
+	}
+
+	var ret TranscriptSegments
+	for i, block := range blocks[1:] {
+		switch strings.Fields(block[0])[0] {
+		case "NOTE", "STYLE", "REGION":
+			continue
+		}
+
+		seg, err := parseCue(block)
+		if err != nil {
+			return nil, fmt.Errorf("%w: cue %d: %w", ErrTranscriptInvalid, i+1, err)
+		}
+		if rest, ok := strings.CutPrefix(seg.Text, "<v "); ok {
+			if speaker, text, ok := strings.Cut(rest, ">"); ok {
+				seg.Speaker = webVTTUnescaper.Replace(strings.TrimSpace(speaker))
+				seg.Text = strings.TrimSuffix(text, "</v>")
+			}
+		}
+		seg.Text = webVTTUnescaper.Replace(seg.Text)
+		ret = append(ret, seg)
+	}
+	return ret, nil
+}
+
+var (
+	webVTTEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	webVTTUnescaper = strings.NewReplacer("&amp;", "&", "&lt;", "<", "&gt;", ">", "&nbsp;", "\u00a0")
+)
+
+// cueText returns the text of a cue without blank lines, which would end the cue
+func cueText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\r", "\n")
+
+	lines := strings.Split(text, "\n")
+	lines = slices.DeleteFunc(lines, func(line string) bool { return strings.TrimSpace(line) == "" })
+	return strings.Join(lines, "\n")
+}
+
+// cueBlocks splits subtitles into blocks of non-empty lines separated by blank lines
+func cueBlocks(data string) [][]string {
+	data = strings.TrimPrefix(data, "\ufeff")
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+
+	var blocks [][]string
+	var current []string
+	for _, line := range strings.Split(data, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(current) > 0 {
+				blocks = append(blocks, current)
+				current = nil
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		blocks = append(blocks, current)
+	}
+	return blocks
+}
+
+// parseCue parses a block made of an optional identifier, a timing line and the text lines
+func parseCue(block []string) (TranscriptSegment, error) {
+	if !strings.Contains(block[0], "-->") {
+		// Identifier (the SRT sequence number, or an optional WebVTT cue ID)
+		block = block[1:]
+	}
+	if len(block) == 0 || !strings.Contains(block[0], "-->") {
+		return TranscriptSegment{}, errors.New("missing timing line")
+	}
+
+	startStr, endStr, _ := strings.Cut(block[0], "-->")
+	// WebVTT cue settings may follow the end time
+	endFields := strings.Fields(endStr)
+	if len(endFields) == 0 {
+		return TranscriptSegment{}, errors.New("missing end time")
+	}
+
+	start, err := parseCueTime(strings.TrimSpace(startStr))
+	if err != nil {
+		return TranscriptSegment{}, err
+	}
+	end, err := parseCueTime(endFields[0])
+	if err != nil {
+		return TranscriptSegment{}, err
+	}
+	if end < start {
+		return TranscriptSegment{}, fmt.Errorf("end time %s is before start time %s", endFields[0], strings.TrimSpace(startStr))
+	}
+
+	return TranscriptSegment{Start: start, End: end, Text: strings.Join(block[1:], "\n")}, nil
+}
+
+// parseCueTime parses a [hh:]mm:ss.mmm (or [hh:]mm:ss,mmm) timestamp into seconds. Minutes and seconds must be less
+// than 60.
+func parseCueTime(s string) (float64, error) {
+	parts := strings.Split(strings.Replace(s, ",", ".", 1), ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds < 0 || seconds >= 60 {
+		return 0, fmt.Errorf("invalid timestamp %q", s)
+	}
+
+	total := seconds
+	multiplier := 60.0
+	for i := len(parts) - 2; i >= 0; i-- {
+		n, err := strconv.Atoi(parts[i])
+		if err != nil || n < 0 || (i == len(parts)-2 && n >= 60) {
+			return 0, fmt.Errorf("invalid timestamp %q", s)
+		}
+		total += float64(n) * multiplier
+		multiplier *= 60
+	}
+	return total, nil
+}
+
+// formatCueTime formats seconds as an hh:mm:ss.mmm timestamp, using sep as the milliseconds separator
+func formatCueTime(seconds float64, sep byte) string {
+	ms := int64(math.Round(max(seconds, 0) * 1000))
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
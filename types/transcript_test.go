@@ -0,0 +1,113 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("Transcript", func() {
+	segments := types.TranscriptSegments{
+		{Start: 0, End: 1.5, Text: "Hello", Speaker: "Alice"},
+		{Start: 3661.25, End: 3662, Text: "Two\nlines"},
+	}
+
+	It("should encode and decode SRT", func() {
+		srt := segments.SRT()
+		Expect(srt).To(Equal("1\n00:00:00,000 --> 00:00:01,500\nHello\n\n2\n01:01:01,250 --> 01:01:02,000\nTwo\nlines\n\n"))
+
+		decoded, err := types.ParseSRT(srt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(types.TranscriptSegments{
+			{Start: 0, End: 1.5, Text: "Hello"},
+			{Start: 3661.25, End: 3662, Text: "Two\nlines"},
+		}))
+	})
+
+	It("should encode and decode WebVTT", func() {
+		vtt := segments.WebVTT()
+		Expect(vtt).To(HavePrefix("WEBVTT\n\n00:00:00.000 --> 00:00:01.500\n<v Alice>Hello\n\n"))
+
+		decoded, err := types.ParseWebVTT(vtt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(segments))
+	})
+
+	It("should decode WebVTT with cue IDs, settings, notes and short timestamps", func() {
+		vtt := "\ufeffWEBVTT - Transcript\r\n\r\nNOTE generated\r\n\r\nintro\r\n00:01.000 --> 00:04.250 align:start\r\n<v Bob>Hi</v>\r\n"
+		decoded, err := types.ParseWebVTT(vtt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(types.TranscriptSegments{{Start: 1, End: 4.25, Text: "Hi", Speaker: "Bob"}}))
+	})
+
+	It("should reject malformed subtitles", func() {
+		_, err := types.ParseWebVTT("00:01.000 --> 00:02.000\nno header\n")
+		Expect(errors.Is(err, types.ErrTranscriptInvalid)).To(BeTrue())
+
+		_, err = types.ParseSRT("1\n00:00:05,000 --> 00:00:01,000\nbackwards\n")
+		Expect(errors.Is(err, types.ErrTranscriptInvalid)).To(BeTrue())
+
+		_, err = types.ParseSRT("1\nnot a timing line\n")
+		Expect(err).To(MatchError(ContainSubstring("cue 1")))
+
+		_, err = types.ParseSRT("1\n00:00:75,000 --> 00:01:00,000\ntext\n")
+		Expect(errors.Is(err, types.ErrTranscriptInvalid)).To(BeTrue())
+
+		_, err = types.ParseSRT("1\n00:75:00,000 --> 01:16:00,000\ntext\n")
+		Expect(errors.Is(err, types.ErrTranscriptInvalid)).To(BeTrue())
+
+		_, err = types.ParseWebVTT("WEBVTT\n\n60:00.000 --> 61:00.000\ntext\n")
+		Expect(errors.Is(err, types.ErrTranscriptInvalid)).To(BeTrue())
+	})
+
+	It("should round-trip SRT text with blank lines and arrows", func() {
+		input := types.TranscriptSegments{
+			{Start: 0, End: 1, Text: "First\n\n\nparagraph\r\n  \r\nend"},
+			{Start: 1, End: 2, Text: "2\n00:00:05,000 --> 00:00:06,000"},
+			{Start: 2, End: 3, Text: "a -> b"},
+		}
+		decoded, err := types.ParseSRT(input.SRT())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(types.TranscriptSegments{
+			{Start: 0, End: 1, Text: "First\nparagraph\nend"},
+			{Start: 1, End: 2, Text: "2\n00:00:05,000 -> 00:00:06,000"},
+			{Start: 2, End: 3, Text: "a -> b"},
+		}))
+	})
+
+	It("should round-trip WebVTT text with markup characters", func() {
+		input := types.TranscriptSegments{
+			{Start: 0, End: 1, Text: "<b>bold</b> & x < y > z", Speaker: "Tom & Jerry"},
+			{Start: 1, End: 2, Text: "a --> b\n\nc &amp; d"},
+			{Start: 2, End: 3, Text: "NOTE this is text"},
+		}
+		vtt := input.WebVTT()
+		Expect(vtt).To(ContainSubstring("<v Tom &amp; Jerry>&lt;b&gt;bold&lt;/b&gt; &amp; x &lt; y &gt; z\n"))
+		Expect(vtt).To(ContainSubstring("a --&gt; b\nc &amp;amp; d\n"))
+
+		decoded, err := types.ParseWebVTT(vtt)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(decoded).To(Equal(types.TranscriptSegments{
+			{Start: 0, End: 1, Text: "<b>bold</b> & x < y > z", Speaker: "Tom & Jerry"},
+			{Start: 1, End: 2, Text: "a --> b\nc &amp; d"},
+			{Start: 2, End: 3, Text: "NOTE this is text"},
+		}))
+	})
+
+	It("should join the segment texts", func() {
+		Expect(segments.Text()).To(Equal("Hello Two\nlines"))
+	})
+
+	It("should decode transcription results with and without segments", func() {
+		var result types.TikTokTranscriptionResult
+		Expect(json.Unmarshal([]byte(`{"transcription_text":"Hello","original_url":"https://www.tiktok.com/@a/video/1"}`), &result)).To(Succeed())
+		Expect(result.Segments).To(BeNil())
+
+		Expect(json.Unmarshal([]byte(`{"transcription_text":"Hello","segments":[{"start":0,"end":1.5,"text":"Hello","confidence":0.9}]}`), &result)).To(Succeed())
+		Expect(result.Segments).To(Equal(types.TranscriptSegments{{Start: 0, End: 1.5, Text: "Hello", Confidence: 0.9}}))
+	})
+})
@@ -185,3 +185,47 @@ type ProfileResultScraper struct {
 	HasGraduatedAccess   bool       `json:"has_graduated_access"`
 	CanHighlightTweets   bool       `json:"can_highlight_tweets"`
 }
+
+type SpaceState string
+
+const (
+	SpaceStateLive      SpaceState = "live"
+	SpaceStateScheduled SpaceState = "scheduled"
+	SpaceStateEnded     SpaceState = "ended"
+	SpaceStateCanceled  SpaceState = "canceled"
+)
+
+// SpaceResult defines the structure of a Twitter Space returned by the getspace capability
+type SpaceResult struct {
+	ID               string     `json:"id"`
+	State            SpaceState `json:"state"`
+	Title            string     `json:"title"`
+	CreatorID        string     `json:"creator_id"`
+	HostIDs          []string   `json:"host_ids"`
+	SpeakerIDs       []string   `json:"speaker_ids"`
+	InvitedUserIDs   []string   `json:"invited_user_ids"`
+	ListenerIDs      []string   `json:"listener_ids"`
+	TopicIDs         []string   `json:"topic_ids"`
+	Lang             string     `json:"lang"`
+	IsTicketed       bool       `json:"is_ticketed"`
+	ParticipantCount int        `json:"participant_count"` // Hosts, speakers and listeners currently in the Space
+	SubscriberCount  int        `json:"subscriber_count"`  // Users who set a reminder for a scheduled Space
+	CreatedAt        *time.Time `json:"created_at,omitempty"`
+	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
+	ScheduledStart   *time.Time `json:"scheduled_start,omitempty"`
+	StartedAt        *time.Time `json:"started_at,omitempty"`
+	EndedAt          *time.Time `json:"ended_at,omitempty"`
+}
+
+// IsLive returns true if the Space is currently running
+func (s *SpaceResult) IsLive() bool {
+	return s.State == SpaceStateLive
+}
+
+// Duration returns how long the Space ran, or 0 if it has not both started and ended
+func (s *SpaceResult) Duration() time.Duration {
+	if s.StartedAt == nil || s.EndedAt == nil {
+		return 0
+	}
+	return s.EndedAt.Sub(*s.StartedAt)
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TrendLocation is a place for which Twitter reports trends, identified by its Yahoo! Where On Earth ID (WOEID)
+type TrendLocation struct {
+	WOEID       int    `json:"woeid"`
+	Name        string `json:"name"`
+	CountryCode string `json:"country_code,omitempty"` // ISO 3166-1 alpha-2, empty for Worldwide
+	PlaceType   string `json:"place_type"`             // "Supername", "Country" or "Town"
+}
+
+// WorldwideWOEID is the WOEID of the worldwide trends, used when no location is requested
+const WorldwideWOEID = 1
+
+// TrendLocations is the catalog of locations accepted for the gettrends capability
+var TrendLocations = []TrendLocation{
+	{WOEID: WorldwideWOEID, Name: "Worldwide", PlaceType: "Supername"},
+
+	{WOEID: 23424747, Name: "Argentina", CountryCode: "AR", PlaceType: "Country"},
+	{WOEID: 23424748, Name: "Australia", CountryCode: "AU", PlaceType: "Country"},
+	{WOEID: 23424768, Name: "Brazil", CountryCode: "BR", PlaceType: "Country"},
+	{WOEID: 23424775, Name: "Canada", CountryCode: "CA", PlaceType: "Country"},
+	{WOEID: 23424819, Name: "France", CountryCode: "FR", PlaceType: "Country"},
+	{WOEID: 23424829, Name: "Germany", CountryCode: "DE", PlaceType: "Country"},
+	{WOEID: 23424848, Name: "India", CountryCode: "IN", PlaceType: "Country"},
+	{WOEID: 23424846, Name: "Indonesia", CountryCode: "ID", PlaceType: "Country"},
+	{WOEID: 23424853, Name: "Italy", CountryCode: "IT", PlaceType: "Country"},
+	{WOEID: 23424856, Name: "Japan", CountryCode: "JP", PlaceType: "Country"},
+	{WOEID: 23424900, Name: "Mexico", CountryCode: "MX", PlaceType: "Country"},
+	{WOEID: 23424909, Name: "Netherlands", CountryCode: "NL", PlaceType: "Country"},
+	{WOEID: 23424908, Name: "Nigeria", CountryCode: "NG", PlaceType: "Country"},
+	{WOEID: 23424934, Name: "Philippines", CountryCode: "PH", PlaceType: "Country"},
+	{WOEID: 23424948, Name: "Singapore", CountryCode: "SG", PlaceType: "Country"},
+	{WOEID: 23424942, Name: "South Africa", CountryCode: "ZA", PlaceType: "Country"},
+	{WOEID: 23424868, Name: "South Korea", CountryCode: "KR", PlaceType: "Country"},
+	{WOEID: 23424950, Name: "Spain", CountryCode: "ES", PlaceType: "Country"},
+	{WOEID: 23424969, Name: "Turkey", CountryCode: "TR", PlaceType: "Country"},
+	{WOEID: 23424975, Name: "United Kingdom", CountryCode: "GB", PlaceType: "Country"},
+	{WOEID: 23424977, Name: "United States", CountryCode: "US", PlaceType: "Country"},
+
+	{WOEID: 638242, Name: "Berlin", CountryCode: "DE", PlaceType: "Town"},
+	{WOEID: 2379574, Name: "Chicago", CountryCode: "US", PlaceType: "Town"},
+	{WOEID: 44418, Name: "London", CountryCode: "GB", PlaceType: "Town"},
+	{WOEID: 2442047, Name: "Los Angeles", CountryCode: "US", PlaceType: "Town"},
+	{WOEID: 766273, Name: "Madrid", CountryCode: "ES", PlaceType: "Town"},
+	{WOEID: 2295411, Name: "Mumbai", CountryCode: "IN", PlaceType: "Town"},
+	{WOEID: 2459115, Name: "New York", CountryCode: "US", PlaceType: "Town"},
+	{WOEID: 615702, Name: "Paris", CountryCode: "FR", PlaceType: "Town"},
+	{WOEID: 2487956, Name: "San Francisco", CountryCode: "US", PlaceType: "Town"},
+	{WOEID: 455827, Name: "Sao Paulo", CountryCode: "BR", PlaceType: "Town"},
+	{WOEID: 1105779, Name: "Sydney", CountryCode: "AU", PlaceType: "Town"},
+	{WOEID: 1118370, Name: "Tokyo", CountryCode: "JP", PlaceType: "Town"},
+	{WOEID: 4118, Name: "Toronto", CountryCode: "CA", PlaceType: "Town"},
+}
+
+// LookupTrendLocation finds a location in TrendLocations by WOEID (e.g. "23424977") or by name, case-insensitively
+// (e.g. "united states")
+func LookupTrendLocation(location string) (TrendLocation, bool) {
+	location = strings.TrimSpace(location)
+	if woeid, err := strconv.Atoi(location); err == nil {
+		return TrendLocationByWOEID(woeid)
+	}
+	for _, l := range TrendLocations {
+		if strings.EqualFold(l.Name, location) {
+			return l, true
+		}
+	}
+	return TrendLocation{}, false
+}
+
+// TrendLocationByWOEID finds a location in TrendLocations by WOEID
+func TrendLocationByWOEID(woeid int) (TrendLocation, bool) {
+	for _, l := range TrendLocations {
+		if l.WOEID == woeid {
+			return l, true
+		}
+	}
+	return TrendLocation{}, false
+}
+
+// TrendResult defines the structure of a single trend returned by the gettrends capability
+type TrendResult struct {
+	Name        string        `json:"name"`                   // e.g. "#GoLang"
+	Query       string        `json:"query"`                  // Search query that returns the trending tweets
+	TweetVolume *int          `json:"tweet_volume,omitempty"` // Tweets in the last 24 hours, nil if Twitter does not report it
+	Rank        int           `json:"rank"`                   // 1-based position in the trends list
+	Location    TrendLocation `json:"location"`
+	AsOf        time.Time     `json:"as_of"` // When the trends list was computed
+}
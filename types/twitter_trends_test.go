@@ -0,0 +1,44 @@
+package types_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("Trends", func() {
+	It("should have unique WOEIDs and names in the catalog", func() {
+		woeids := map[int]bool{}
+		names := map[string]bool{}
+		for _, l := range types.TrendLocations {
+			Expect(woeids).NotTo(HaveKey(l.WOEID))
+			Expect(names).NotTo(HaveKey(l.Name))
+			woeids[l.WOEID] = true
+			names[l.Name] = true
+		}
+	})
+
+	It("should look up locations by WOEID or name", func() {
+		l, ok := types.LookupTrendLocation(" 2459115 ")
+		Expect(ok).To(BeTrue())
+		Expect(l.Name).To(Equal("New York"))
+
+		l, ok = types.LookupTrendLocation("JAPAN")
+		Expect(ok).To(BeTrue())
+		Expect(l.WOEID).To(Equal(23424856))
+
+		_, ok = types.LookupTrendLocation("42")
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should decode a trend with an unknown tweet volume", func() {
+		var trend types.TrendResult
+		Expect(json.Unmarshal([]byte(`{"name":"#GoLang","query":"%23GoLang","rank":1,"location":{"woeid":1,"name":"Worldwide","place_type":"Supername"},"as_of":"2025-01-02T03:04:05Z"}`), &trend)).To(Succeed())
+		Expect(trend.TweetVolume).To(BeNil())
+		Expect(trend.Location.WOEID).To(Equal(types.WorldwideWOEID))
+		Expect(trend.AsOf.IsZero()).To(BeFalse())
+	})
+})
@@ -14,15 +14,54 @@ type WebQueryType string
 
 const (
 	WebScraper WebQueryType = "scraper"
+	WebSitemap WebQueryType = "sitemap" // Fetches the pages listed in sitemaps, without following links
 )
 
+// WebURLGlob is a URL glob as accepted by the Apify website-content-crawler, e.g. {"glob": "https://example.com/**"}
+type WebURLGlob struct {
+	Glob string `json:"glob"`
+}
+
 // WebScraperRequest represents the customizable configuration for web scraping operations
+// see https://apify.com/apify/website-content-crawler/input-schema
 type WebScraperRequest struct {
 	StartUrls            []WebStartURL `json:"startUrls"`
 	MaxCrawlDepth        int           `json:"maxCrawlDepth"`
 	MaxCrawlPages        int           `json:"maxCrawlPages"`
 	RespectRobotsTxtFile bool          `json:"respectRobotsTxtFile"`
 	SaveMarkdown         bool          `json:"saveMarkdown"`
+	IncludeUrlGlobs      []WebURLGlob  `json:"includeUrlGlobs,omitempty"` // If set, the actor only follows matching URLs
+	ExcludeUrlGlobs      []WebURLGlob  `json:"excludeUrlGlobs,omitempty"` // The actor never follows matching URLs
+
+	// The actor has no crawl scope or regex inputs, so these are not sent to it: the worker applies them to the
+	// discovered URLs through URLFilter. Set the patterns with SetURLPatterns to keep the actor globs in sync.
+	CrawlScope         WebCrawlScope   `json:"-"`
+	IncludeUrlPatterns []WebURLPattern `json:"-"` // If set, only matching URLs are followed
+	ExcludeUrlPatterns []WebURLPattern `json:"-"` // Matching URLs are never followed
+}
+
+// SetURLPatterns sets the include and exclude patterns, and the actor globs equivalent to them. Regexes have no actor
+// equivalent: they are left out of ExcludeUrlGlobs, and IncludeUrlGlobs is only set if all the include patterns are
+// globs, since the actor would otherwise skip the URLs that only match a regex.
+func (r *WebScraperRequest) SetURLPatterns(include, exclude []WebURLPattern) {
+	r.IncludeUrlPatterns = include
+	r.ExcludeUrlPatterns = exclude
+
+	r.IncludeUrlGlobs = nil
+	for _, p := range include {
+		if p.Glob == "" {
+			r.IncludeUrlGlobs = nil
+			break
+		}
+		r.IncludeUrlGlobs = append(r.IncludeUrlGlobs, WebURLGlob{Glob: p.Glob})
+	}
+
+	r.ExcludeUrlGlobs = nil
+	for _, p := range exclude {
+		if p.Glob != "" {
+			r.ExcludeUrlGlobs = append(r.ExcludeUrlGlobs, WebURLGlob{Glob: p.Glob})
+		}
+	}
 }
 
 // WebCrawlInfo contains information about the crawling process
@@ -0,0 +1,293 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+
+	"github.com/masa-finance/tee-types/pkg/util"
+)
+
+var ErrWebURLPatternInvalid = errors.New("invalid URL pattern")
+
+type WebCrawlScope string
+
+const (
+	WebScopeSameHost   WebCrawlScope = "same_host"   // Only URLs with the scheme, host and port of a start URL
+	WebScopeSameDomain WebCrawlScope = "same_domain" // URLs on the registrable domain of a start URL, including its subdomains, with any scheme or port
+	WebScopePathPrefix WebCrawlScope = "path_prefix" // Only URLs with the scheme, host and port of a start URL and under its directory
+)
+
+var AllWebCrawlScopes = util.NewSet(WebScopeSameHost, WebScopeSameDomain, WebScopePathPrefix)
+
+type WebRobotsPolicy string
+
+const (
+	WebRobotsIgnore  WebRobotsPolicy = "ignore"  // robots.txt is not fetched
+	WebRobotsRespect WebRobotsPolicy = "respect" // Disallowed URLs are skipped and the crawl delay is honored
+)
+
+var AllWebRobotsPolicies = util.NewSet(WebRobotsIgnore, WebRobotsRespect)
+
+// WebURLPattern matches URLs with either a glob or a regular expression. Globs match the whole URL: `*` matches any
+// characters except '/', `**` matches any characters, `?` matches a single character except '/', `{a,b}` matches any
+// of the alternatives and `\` escapes the next character. Regexes match anywhere in the URL unless anchored.
+// In JSON a pattern is either an object such as {"glob": "https://example.com/docs/**"} or a bare glob string.
+type WebURLPattern struct {
+	Glob  string `json:"glob,omitempty"`
+	Regex string `json:"regex,omitempty"`
+}
+
+// UnmarshalJSON decodes a pattern object or a bare glob string
+func (p *WebURLPattern) UnmarshalJSON(data []byte) error {
+	var glob string
+	if err := json.Unmarshal(data, &glob); err == nil {
+		*p = WebURLPattern{Glob: glob}
+		return nil
+	}
+
+	type Alias WebURLPattern
+	return json.Unmarshal(data, (*Alias)(p))
+}
+
+// String returns the glob or the regex of the pattern
+func (p WebURLPattern) String() string {
+	if p.Regex != "" {
+		return p.Regex
+	}
+	return p.Glob
+}
+
+// Compile returns the regular expression equivalent to the pattern
+func (p WebURLPattern) Compile() (*regexp.Regexp, error) {
+	var expr string
+	switch {
+	case p.Glob != "" && p.Regex != "":
+		return nil, fmt.Errorf("%w: glob and regex are mutually exclusive", ErrWebURLPatternInvalid)
+	case p.Glob != "":
+		var err error
+		if expr, err = globToRegexp(p.Glob); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrWebURLPatternInvalid, p.Glob, err)
+		}
+	case p.Regex != "":
+		expr = p.Regex
+	default:
+		return nil, fmt.Errorf("%w: either glob or regex is required", ErrWebURLPatternInvalid)
+	}
+
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrWebURLPatternInvalid, p.String(), err)
+	}
+	return re, nil
+}
+
+func globToRegexp(glob string) (string, error) {
+	var sb strings.Builder
+	sb.WriteString("^")
+
+	inGroup := false
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '\\':
+			if i+1 == len(glob) {
+				return "", errors.New("trailing escape character")
+			}
+			i++
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString(".*")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '{':
+			if inGroup {
+				return "", errors.New("nested alternatives are not supported")
+			}
+			inGroup = true
+			sb.WriteString("(?:")
+		case '}':
+			if !inGroup {
+				return "", errors.New("unbalanced '}'")
+			}
+			inGroup = false
+			sb.WriteString(")")
+		case ',':
+			if inGroup {
+				sb.WriteString("|")
+			} else {
+				sb.WriteString(",")
+			}
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	if inGroup {
+		return "", errors.New("unbalanced '{'")
+	}
+
+	sb.WriteString("$")
+	return sb.String(), nil
+}
+
+// WebURLFilter decides which of the URLs discovered during a crawl may be followed
+type WebURLFilter struct {
+	scope   WebCrawlScope
+	starts  []webScopeOrigin
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// webPageExtensions are the extensions of the start URLs that name a page rather than a directory
+var webPageExtensions = util.NewSet(".html", ".htm", ".xhtml", ".shtml", ".php", ".asp", ".aspx", ".jsp", ".cfm", ".xml", ".txt", ".md", ".pdf")
+
+// webScopeOrigin is the part of a URL that the crawl scopes compare
+type webScopeOrigin struct {
+	scheme string
+	host   string
+	port   string
+	dir    string // the directory of the path, with a trailing '/'
+}
+
+func newWebScopeOrigin(u *url.URL) webScopeOrigin {
+	scheme := strings.ToLower(u.Scheme)
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[scheme]
+	}
+
+	// A last segment with a page extension (e.g. /docs/index.html) names a page, otherwise (e.g. /docs or
+	// /api/v1.2) a directory
+	dir := u.Path
+	if last := dir[strings.LastIndex(dir, "/")+1:]; webPageExtensions.Contains(strings.ToLower(path.Ext(last))) {
+		dir = dir[:len(dir)-len(last)]
+	}
+	if !strings.HasSuffix(dir, "/") {
+		dir += "/"
+	}
+
+	return webScopeOrigin{
+		scheme: scheme,
+		host:   strings.TrimSuffix(strings.ToLower(u.Hostname()), "."),
+		port:   port,
+		dir:    dir,
+	}
+}
+
+// NewWebURLFilter returns a filter for a crawl with the given start URLs, scope and patterns. An empty scope is
+// WebScopeSameHost. For WebScopePathPrefix the prefix is the directory of each start URL: /docs/index.html and /docs/
+// both allow everything under /docs/, and so does /docs, whose last segment is not a page (see webPageExtensions).
+func NewWebURLFilter(startURLs []string, scope WebCrawlScope, include, exclude []WebURLPattern) (*WebURLFilter, error) {
+	if scope == "" {
+		scope = WebScopeSameHost
+	}
+	if !AllWebCrawlScopes.Contains(scope) {
+		return nil, fmt.Errorf("invalid crawl scope: %q", scope)
+	}
+
+	f := &WebURLFilter{scope: scope}
+	for _, raw := range startURLs {
+		u, err := url.Parse(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start URL %q: %w", raw, err)
+		}
+		f.starts = append(f.starts, newWebScopeOrigin(u))
+	}
+
+	var err error
+	if f.include, err = compileWebURLPatterns(include); err != nil {
+		return nil, err
+	}
+	if f.exclude, err = compileWebURLPatterns(exclude); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func compileWebURLPatterns(patterns []WebURLPattern) ([]*regexp.Regexp, error) {
+	ret := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		re, err := p.Compile()
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, re)
+	}
+	return ret, nil
+}
+
+// URLFilter returns the filter for the start URLs, scope and patterns of the request
+func (r WebScraperRequest) URLFilter() (*WebURLFilter, error) {
+	startURLs := make([]string, len(r.StartUrls))
+	for i, s := range r.StartUrls {
+		startURLs[i] = s.URL
+	}
+	return NewWebURLFilter(startURLs, r.CrawlScope, r.IncludeUrlPatterns, r.ExcludeUrlPatterns)
+}
+
+// Allows returns true if the crawl may follow rawURL: it must be an http(s) URL in the scope of at least one start URL,
+// match at least one of the include patterns (if there are any) and none of the exclude patterns
+func (f *WebURLFilter) Allows(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return false
+	}
+
+	origin := newWebScopeOrigin(u)
+	inScope := false
+	for _, start := range f.starts {
+		if f.inScope(origin, u.Path, start) {
+			inScope = true
+			break
+		}
+	}
+	if !inScope {
+		return false
+	}
+
+	for _, re := range f.exclude {
+		if re.MatchString(rawURL) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(rawURL) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *WebURLFilter) inScope(origin webScopeOrigin, path string, start webScopeOrigin) bool {
+	if f.scope == WebScopeSameDomain {
+		return registrableDomain(origin.host) == registrableDomain(start.host)
+	}
+
+	sameOrigin := origin.scheme == start.scheme && origin.host == start.host && origin.port == start.port
+	if f.scope == WebScopePathPrefix {
+		return sameOrigin && (strings.HasPrefix(path, start.dir) || path+"/" == start.dir)
+	}
+	return sameOrigin
+}
+
+// registrableDomain returns the domain directly under the public suffix of host (e.g. "example.co.uk" for
+// "docs.example.co.uk"), or host itself for IP addresses and single-label hosts
+func registrableDomain(host string) string {
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
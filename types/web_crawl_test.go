@@ -0,0 +1,130 @@
+package types_test
+
+import (
+	"encoding/json"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/masa-finance/tee-types/types"
+)
+
+var _ = Describe("Web crawl rules", func() {
+	Describe("WebURLPattern", func() {
+		It("should unmarshal bare globs and pattern objects", func() {
+			var patterns []types.WebURLPattern
+			Expect(json.Unmarshal([]byte(`["https://example.com/**", {"regex": "^https://"}]`), &patterns)).To(Succeed())
+			Expect(patterns).To(Equal([]types.WebURLPattern{
+				{Glob: "https://example.com/**"},
+				{Regex: "^https://"},
+			}))
+		})
+
+		DescribeTable("glob matching",
+			func(glob, rawURL string, matches bool) {
+				re, err := types.WebURLPattern{Glob: glob}.Compile()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(re.MatchString(rawURL)).To(Equal(matches))
+			},
+			Entry("double star crosses path segments", "https://example.com/**", "https://example.com/a/b/c", true),
+			Entry("single star stays in a segment", "https://example.com/*", "https://example.com/a/b", false),
+			Entry("single star within a segment", "https://example.com/*.html", "https://example.com/index.html", true),
+			Entry("question mark matches one character", "https://example.com/v?/", "https://example.com/v2/", true),
+			Entry("alternatives", "https://example.com/{docs,blog}/**", "https://example.com/blog/post", true),
+			Entry("alternatives do not match other paths", "https://example.com/{docs,blog}/**", "https://example.com/shop/item", false),
+			Entry("globs match the whole URL", "https://example.com/docs", "https://example.com/docs/intro", false),
+			Entry("escaped characters are literal", `https://example.com/search\?q=*`, "https://example.com/search?q=go", true),
+			Entry("dots are literal", "https://example.com/**", "https://exampleXcom/a", false),
+		)
+
+		It("should match regexes anywhere in the URL", func() {
+			re, err := types.WebURLPattern{Regex: `/blog/\d{4}/`}.Compile()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(re.MatchString("https://example.com/blog/2024/post")).To(BeTrue())
+			Expect(re.MatchString("https://example.com/blog/latest")).To(BeFalse())
+		})
+
+		DescribeTable("invalid patterns",
+			func(pattern types.WebURLPattern) {
+				_, err := pattern.Compile()
+				Expect(errors.Is(err, types.ErrWebURLPatternInvalid)).To(BeTrue())
+			},
+			Entry("empty", types.WebURLPattern{}),
+			Entry("both glob and regex", types.WebURLPattern{Glob: "**", Regex: ".*"}),
+			Entry("unbalanced alternatives", types.WebURLPattern{Glob: "https://example.com/{a,b"}),
+			Entry("nested alternatives", types.WebURLPattern{Glob: "{a,{b,c}}"}),
+			Entry("trailing escape", types.WebURLPattern{Glob: `https://example.com/\`}),
+			Entry("invalid regex", types.WebURLPattern{Regex: "("}),
+		)
+	})
+
+	Describe("WebURLFilter", func() {
+		DescribeTable("scopes",
+			func(scope types.WebCrawlScope, rawURL string, allowed bool) {
+				filter, err := types.NewWebURLFilter([]string{"https://docs.example.co.uk/guide/"}, scope, nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filter.Allows(rawURL)).To(Equal(allowed))
+			},
+			Entry("same host", types.WebScopeSameHost, "https://docs.example.co.uk/api", true),
+			Entry("same host is case insensitive", types.WebScopeSameHost, "https://DOCS.example.co.uk/api", true),
+			Entry("same host rejects other subdomains", types.WebScopeSameHost, "https://www.example.co.uk/", false),
+			Entry("empty scope is same host", types.WebCrawlScope(""), "https://www.example.co.uk/", false),
+			Entry("same domain accepts other subdomains", types.WebScopeSameDomain, "https://www.example.co.uk/", true),
+			Entry("same domain accepts the apex domain", types.WebScopeSameDomain, "https://example.co.uk/", true),
+			Entry("same domain rejects other domains under the public suffix", types.WebScopeSameDomain, "https://other.co.uk/", false),
+			Entry("path prefix accepts the start path", types.WebScopePathPrefix, "https://docs.example.co.uk/guide", true),
+			Entry("path prefix accepts children", types.WebScopePathPrefix, "https://docs.example.co.uk/guide/install", true),
+			Entry("path prefix rejects siblings", types.WebScopePathPrefix, "https://docs.example.co.uk/guidelines", false),
+			Entry("path prefix rejects other hosts", types.WebScopePathPrefix, "https://www.example.co.uk/guide/install", false),
+			Entry("non-HTTP URLs are rejected", types.WebScopeSameHost, "ftp://docs.example.co.uk/guide", false),
+			Entry("same host accepts the default port", types.WebScopeSameHost, "https://docs.example.co.uk:443/api", true),
+			Entry("same host rejects other ports", types.WebScopeSameHost, "https://docs.example.co.uk:8080/api", false),
+			Entry("same host rejects other schemes", types.WebScopeSameHost, "http://docs.example.co.uk/api", false),
+			Entry("path prefix rejects other ports", types.WebScopePathPrefix, "https://docs.example.co.uk:8443/guide/install", false),
+			Entry("same domain ignores scheme and port", types.WebScopeSameDomain, "http://www.example.co.uk:8080/", true),
+		)
+
+		DescribeTable("path prefix directories",
+			func(start, rawURL string, expected bool) {
+				filter, err := types.NewWebURLFilter([]string{start}, types.WebScopePathPrefix, nil, nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filter.Allows(rawURL)).To(Equal(expected))
+			},
+			Entry("a page allows its siblings", "https://a.com/docs/index.html", "https://a.com/docs/other.html", true),
+			Entry("a page allows its directory", "https://a.com/docs/index.html", "https://a.com/docs/", true),
+			Entry("a page rejects its parent", "https://a.com/docs/index.html", "https://a.com/blog/", false),
+			Entry("an extensionless path is a directory", "https://a.com/docs", "https://a.com/docs/intro", true),
+			Entry("an extensionless path rejects siblings", "https://a.com/docs", "https://a.com/docsearch", false),
+			Entry("the root allows everything", "https://a.com", "https://a.com/any/page", true),
+			Entry("a dotted segment is a directory", "https://a.com/api/v1.2", "https://a.com/api/v1.2/users", true),
+			Entry("a dotted segment rejects its siblings", "https://a.com/api/v1.2", "https://a.com/api/v2", false),
+		)
+
+		It("should accept URLs in the scope of any start URL", func() {
+			filter, err := types.NewWebURLFilter([]string{"https://example.com", "https://example.org"}, types.WebScopeSameHost, nil, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Allows("https://example.org/about")).To(BeTrue())
+			Expect(filter.Allows("https://example.net/about")).To(BeFalse())
+		})
+
+		It("should apply include and exclude patterns", func() {
+			filter, err := types.WebScraperRequest{
+				StartUrls:          []types.WebStartURL{{URL: "https://example.com", Method: "GET"}},
+				IncludeUrlPatterns: []types.WebURLPattern{{Glob: "https://example.com/{docs,blog}/**"}},
+				ExcludeUrlPatterns: []types.WebURLPattern{{Regex: `\.pdf$`}},
+			}.URLFilter()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filter.Allows("https://example.com/docs/intro")).To(BeTrue())
+			Expect(filter.Allows("https://example.com/docs/manual.pdf")).To(BeFalse())
+			Expect(filter.Allows("https://example.com/shop/item")).To(BeFalse())
+		})
+
+		It("should fail with an invalid scope or pattern", func() {
+			_, err := types.NewWebURLFilter([]string{"https://example.com"}, "anywhere", nil, nil)
+			Expect(err).To(HaveOccurred())
+			_, err = types.NewWebURLFilter([]string{"https://example.com"}, types.WebScopeSameHost, nil, []types.WebURLPattern{{}})
+			Expect(errors.Is(err, types.ErrWebURLPatternInvalid)).To(BeTrue())
+		})
+	})
+})